@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/annotations"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var (
+	annotationsFormat string
+	annotationsOut    string
+)
+
+var annotationsCmd = &cobra.Command{
+	Use:   "annotations",
+	Short: "List and export paragraph highlights and notes",
+	Long: `List and export the highlights and notes made in the browse reader.
+
+In an article, press p to select a paragraph, then h to highlight it or n
+to attach a note. Annotations are kept per article and follow their
+paragraph when the article is reflowed or shown in columns.
+
+Examples:
+  economist annotations list
+  economist annotations export > memo-quotes.md
+  economist annotations export https://www.economist.com/... --format json`,
+}
+
+var annotationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List annotated articles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		docs, err := annotations.List()
+		if err != nil {
+			return err
+		}
+		printAnnotatedArticles(docs)
+		return nil
+	},
+}
+
+var annotationsExportCmd = &cobra.Command{
+	Use:   "export [url]",
+	Short: "Export quotes and notes as Markdown or JSON",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runAnnotationsExport,
+}
+
+func init() {
+	annotationsExportCmd.Flags().StringVarP(&annotationsFormat, "format", "f", "markdown", "Output format: markdown or json")
+	annotationsExportCmd.Flags().StringVarP(&annotationsOut, "output", "o", "", "Write to a file instead of stdout")
+
+	annotationsCmd.AddCommand(annotationsListCmd, annotationsExportCmd)
+	rootCmd.AddCommand(annotationsCmd)
+}
+
+func runAnnotationsExport(cmd *cobra.Command, args []string) error {
+	if annotationsFormat != "markdown" && annotationsFormat != "json" {
+		return appErrors.NewUserError("unknown format %q (use markdown or json)", annotationsFormat)
+	}
+
+	var docs []annotations.Document
+	if len(args) == 1 {
+		doc, ok, err := annotations.Lookup(args[0])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return appErrors.NewUserError("no annotations on %s", args[0])
+		}
+		docs = []annotations.Document{*doc}
+	} else {
+		var err error
+		if docs, err = annotations.List(); err != nil {
+			return err
+		}
+	}
+
+	out := os.Stdout
+	if annotationsOut != "" {
+		file, err := os.Create(annotationsOut)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if annotationsFormat == "json" {
+		return writeAnnotationsJSON(out, docs)
+	}
+	_, err := out.WriteString(annotations.ToMarkdown(docs))
+	return err
+}
+
+type annotationOutput struct {
+	Quote     string `json:"quote"`
+	Note      string `json:"note,omitempty"`
+	Highlight bool   `json:"highlight"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type annotatedArticleOutput struct {
+	Title       string             `json:"title"`
+	URL         string             `json:"url"`
+	Section     string             `json:"section,omitempty"`
+	Annotations []annotationOutput `json:"annotations"`
+}
+
+func writeAnnotationsJSON(out *os.File, docs []annotations.Document) error {
+	entries := make([]annotatedArticleOutput, 0, len(docs))
+	for _, doc := range docs {
+		entry := annotatedArticleOutput{Title: doc.Title, URL: doc.URL, Section: doc.Section}
+		entry.Annotations = make([]annotationOutput, 0, len(doc.Annotations))
+		for _, a := range doc.Annotations {
+			entry.Annotations = append(entry.Annotations, annotationOutput{
+				Quote:     a.Quote,
+				Note:      a.Note,
+				Highlight: a.Highlight,
+				UpdatedAt: a.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		}
+		entries = append(entries, entry)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
+
+func printAnnotatedArticles(docs []annotations.Document) {
+	if len(docs) == 0 {
+		fmt.Println("No annotations.")
+		return
+	}
+
+	styles := ui.NewBrowseStyles(noColor)
+	numWidth := len(fmt.Sprintf("%d", len(docs)))
+	for i, doc := range docs {
+		notes := 0
+		for _, a := range doc.Annotations {
+			if a.Note != "" {
+				notes++
+			}
+		}
+		detail := fmt.Sprintf("%d annotated paragraphs · %d notes", len(doc.Annotations), notes)
+		if doc.Section != "" {
+			detail = doc.Section + " · " + detail
+		}
+		fmt.Printf("%s %s\n",
+			styles.Title.Render(fmt.Sprintf("%*d.", numWidth, i+1)),
+			styles.Title.Render(doc.Title),
+		)
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(detail))
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(doc.URL))
+	}
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var (
+	bookmarkTags   []string
+	bookmarkNote   string
+	bookmarkTitle  string
+	bookmarkTag    string
+	bookmarksJSON  bool
+	bookmarksTitle string
+	bookmarksOut   string
+)
+
+var bookmarksCmd = &cobra.Command{
+	Use:   "bookmarks",
+	Short: "Manage bookmarked articles to read later",
+	Long: `Manage the reading list. Bookmarks keep the headline, tags and a note;
+use 'library' to keep an article's full text offline.
+
+Bookmark headlines with Ctrl+B in browse, where they also appear as the
+Saved section. Bookmarks can be referred to by URL or by their number in
+'bookmarks list'.
+
+Examples:
+  economist bookmarks add https://www.economist.com/... --tag weekly --note "for Friday"
+  economist bookmarks list --tag weekly
+  economist bookmarks rm 2
+  economist bookmarks export --tag weekly > reading-list.md`,
+}
+
+var bookmarksAddCmd = &cobra.Command{
+	Use:   "add <url>",
+	Short: "Bookmark an article",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b := bookmarks.Bookmark{URL: args[0], Title: bookmarkTitle, Tags: bookmarkTags, Note: bookmarkNote}
+		if b.Title == "" && !bookmarks.Contains(b.URL) {
+			fillBookmarkFromArticle(&b)
+		}
+		if err := bookmarks.Add(b); err != nil {
+			return err
+		}
+		fmt.Printf("Bookmarked %s\n", b.URL)
+		return nil
+	},
+}
+
+var bookmarksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bookmarks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := bookmarks.List(bookmarkTag)
+		if err != nil {
+			return err
+		}
+		if bookmarksJSON {
+			return writeBookmarksJSON(os.Stdout, list)
+		}
+		printBookmarks(list)
+		return nil
+	},
+}
+
+var bookmarksRmCmd = &cobra.Command{
+	Use:   "rm <url|number>...",
+	Short: "Remove bookmarks",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Resolve every reference before removing any, so numbers refer
+		// to the list as it was when the command started.
+		urls := make([]string, 0, len(args))
+		for _, ref := range args {
+			b, err := bookmarks.Resolve(ref)
+			if err != nil {
+				return err
+			}
+			urls = append(urls, b.URL)
+		}
+		for _, url := range urls {
+			if _, err := bookmarks.Remove(url); err != nil {
+				return err
+			}
+			fmt.Printf("Removed %s\n", url)
+		}
+		return nil
+	},
+}
+
+var bookmarksExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export bookmarks as a Markdown reading list",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := bookmarks.List(bookmarkTag)
+		if err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if bookmarksOut != "" {
+			file, err := os.Create(bookmarksOut)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			out = file
+		}
+
+		if bookmarksJSON {
+			return writeBookmarksJSON(out, list)
+		}
+		title := bookmarksTitle
+		if title == "" {
+			title = "Reading list"
+			if bookmarkTag != "" {
+				title = fmt.Sprintf("Reading list: %s", bookmarkTag)
+			}
+		}
+		_, err = out.WriteString(bookmarks.ToMarkdown(title, list))
+		return err
+	},
+}
+
+func init() {
+	bookmarksAddCmd.Flags().StringSliceVarP(&bookmarkTags, "tag", "t", nil, "Tag the bookmark (repeatable, or comma-separated)")
+	bookmarksAddCmd.Flags().StringVar(&bookmarkNote, "note", "", "Attach a note")
+	bookmarksAddCmd.Flags().StringVar(&bookmarkTitle, "title", "", "Title to show (default: the article's headline)")
+	bookmarksListCmd.Flags().StringVarP(&bookmarkTag, "tag", "t", "", "Only bookmarks with this tag")
+	bookmarksListCmd.Flags().BoolVar(&bookmarksJSON, "json", false, "Output JSON")
+	bookmarksExportCmd.Flags().StringVarP(&bookmarkTag, "tag", "t", "", "Only bookmarks with this tag")
+	bookmarksExportCmd.Flags().BoolVar(&bookmarksJSON, "json", false, "Export JSON instead of Markdown")
+	bookmarksExportCmd.Flags().StringVar(&bookmarksTitle, "title", "", "Heading for the Markdown list")
+	bookmarksExportCmd.Flags().StringVarP(&bookmarksOut, "output", "o", "", "Write to a file instead of stdout")
+
+	bookmarksCmd.AddCommand(bookmarksAddCmd, bookmarksListCmd, bookmarksRmCmd, bookmarksExportCmd)
+	rootCmd.AddCommand(bookmarksCmd)
+}
+
+// fillBookmarkFromArticle takes the headline details from the article.
+// Bookmarking still works when it cannot be fetched; the URL stands in
+// for the title.
+func fillBookmarkFromArticle(b *bookmarks.Bookmark) {
+	art, err := fetch.FetchArticle(b.URL, fetch.Options{Debug: debugMode})
+	if err != nil {
+		logging.Debugf(debugMode, "bookmarks: fetch %s: %v", b.URL, err)
+		return
+	}
+	b.Title = art.Title
+	b.Description = art.Subtitle
+	b.Section = art.Section
+	if !art.Published.IsZero() {
+		b.PubDate = art.Published.Format("Mon, 02 Jan 2006 15:04:05 -0700")
+	}
+}
+
+type bookmarkOutput struct {
+	Title       string   `json:"title"`
+	URL         string   `json:"url"`
+	Description string   `json:"description,omitempty"`
+	Section     string   `json:"section,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Note        string   `json:"note,omitempty"`
+	AddedAt     string   `json:"added_at"`
+}
+
+func writeBookmarksJSON(out *os.File, list []bookmarks.Bookmark) error {
+	entries := make([]bookmarkOutput, 0, len(list))
+	for _, b := range list {
+		entries = append(entries, bookmarkOutput{
+			Title:       b.Title,
+			URL:         b.URL,
+			Description: b.Description,
+			Section:     b.Section,
+			Tags:        b.Tags,
+			Note:        b.Note,
+			AddedAt:     b.AddedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(append(data, '\n'))
+	return err
+}
+
+func printBookmarks(list []bookmarks.Bookmark) {
+	if len(list) == 0 {
+		fmt.Println("No bookmarks.")
+		return
+	}
+
+	styles := ui.NewBrowseStyles(noColor)
+	numWidth := len(fmt.Sprintf("%d", len(list)))
+	for i, b := range list {
+		badge := ui.SectionLabel(b.Section)
+		if badge != "" {
+			badge = styles.Badge.Render(badge) + " "
+		}
+		fmt.Printf("%s %s%s\n",
+			styles.Title.Render(fmt.Sprintf("%*d.", numWidth, i+1)),
+			badge,
+			styles.Title.Render(b.Title),
+		)
+		detail := fmt.Sprintf("added %s", b.AddedAt.Local().Format("2 Jan 2006"))
+		if len(b.Tags) > 0 {
+			detail += " · #" + strings.Join(b.Tags, " #")
+		}
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(detail))
+		if b.Note != "" {
+			fmt.Printf("%*s %s\n", numWidth+1, "", styles.Subtitle.Render(b.Note))
+		}
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(b.URL))
+	}
+}
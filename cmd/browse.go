@@ -19,15 +19,21 @@ var browseCmd = &cobra.Command{
 	Long: `Browse headlines in an interactive TUI.
 
 Use ↑/↓ to navigate, Enter to read, b to go back, c to toggle columns, q to quit.
+Ctrl+O switches between the section list and the front page, which shows
+the top headlines of every section.
 
 Examples:
   economist browse
-  economist browse finance`,
+  economist browse finance
+  economist browse --front-page`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runBrowse,
 }
 
+var browseFrontPage bool
+
 func init() {
+	browseCmd.Flags().BoolVar(&browseFrontPage, "front-page", false, "Start on the front page of every section")
 	rootCmd.AddCommand(browseCmd)
 }
 
@@ -55,5 +61,5 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 		section = args[0]
 	}
 
-	return browse.Run(section, browse.Options{Debug: debugMode, NoColor: noColor})
+	return browse.Run(section, browse.Options{Debug: debugMode, NoColor: noColor, FrontPage: browseFrontPage})
 }
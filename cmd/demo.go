@@ -39,6 +39,6 @@ func runDemo(cmd *cobra.Command, args []string) error {
 	}
 
 	source := demo.NewSource()
-	opts := browse.Options{Debug: debugMode, NoColor: noColor, Source: source}
+	opts := browse.Options{Debug: debugMode, NoColor: noColor, Source: source, Ephemeral: true}
 	return browse.Run(section, opts)
 }
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/digest"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/readstate"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+const (
+	digestLastRunScope = "digest"
+	digestDefaultSince = 24 * time.Hour
+)
+
+var (
+	digestSince  string
+	digestLimit  int
+	digestFull   bool
+	digestFormat string
+	digestOut    string
+	digestTitle  string
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest [section[:N]...]",
+	Short: "Assemble a briefing of the newest articles across sections",
+	Long: `Assemble one briefing document from the headlines published since a
+given time, section by section in the order given. A section written as
+section:N shows at most N items; --number caps the others.
+
+--since takes a duration (12h, 3d), a date or time (2026-10-15,
+2026-10-15T08:00), or "last" for everything since the previous run that
+also used --since last (a day on the first run).
+
+Formats: terminal (default), markdown, html, text.
+
+Examples:
+  economist digest
+  economist digest leaders:3 finance business:5 --since 12h
+  economist digest --full --format markdown -o standup.md
+  economist digest --since last --format text | mail -s "Morning digest" me@example.com`,
+	RunE: runDigest,
+}
+
+func init() {
+	digestCmd.Flags().StringVar(&digestSince, "since", "24h", "Only articles published since: duration, date, or \"last\"")
+	digestCmd.Flags().IntVarP(&digestLimit, "number", "n", 5, "Items per section (0 = all)")
+	digestCmd.Flags().BoolVar(&digestFull, "full", false, "Include each article's full text")
+	digestCmd.Flags().StringVarP(&digestFormat, "format", "f", "terminal", "Output format: terminal, markdown, html or text")
+	digestCmd.Flags().StringVarP(&digestOut, "output", "o", "", "Write to a file instead of stdout")
+	digestCmd.Flags().StringVar(&digestTitle, "title", "", "Digest title (default: names the day)")
+	rootCmd.AddCommand(digestCmd)
+}
+
+func runDigest(cmd *cobra.Command, args []string) error {
+	switch digestFormat {
+	case "terminal", "markdown", "html", "text":
+	default:
+		return appErrors.NewUserError("unknown format %q (use terminal, markdown, html or text)", digestFormat)
+	}
+
+	specs := make([]digest.SectionSpec, 0, len(args))
+	for _, arg := range args {
+		spec, err := digest.ParseSectionSpec(arg)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+
+	var state *readstate.State
+	if digestSince == "last" {
+		var err error
+		if state, err = readstate.Load(); err != nil {
+			logging.Debugf(debugMode, "digest: read state: %v", err)
+		}
+	}
+	since, err := parseDigestSince(digestSince, state, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fetcher := digest.Fetcher{}
+	if digestFull {
+		fetcher.Article = func(url string) (*article.Article, error) {
+			art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "skipped body of %s: %v\n", url, err)
+			}
+			return art, err
+		}
+	}
+
+	started := time.Now()
+	d, err := digest.Build(digest.Options{
+		Title:    digestTitle,
+		Sections: specs,
+		Since:    since,
+		Limit:    digestLimit,
+		Full:     digestFull,
+	}, fetcher)
+	if err != nil {
+		return err
+	}
+	for _, section := range d.Sections {
+		if section.Err != nil {
+			fmt.Fprintf(os.Stderr, "skipped %s: %v\n", section.Name, section.Err)
+		}
+	}
+
+	if err := writeDigest(d); err != nil {
+		return err
+	}
+
+	if state != nil {
+		state.SetLastRun(digestLastRunScope, started)
+		if err := state.Save(); err != nil {
+			logging.Debugf(debugMode, "digest: save read state: %v", err)
+		}
+	}
+	return nil
+}
+
+func writeDigest(d *digest.Digest) error {
+	var out string
+	switch digestFormat {
+	case "markdown":
+		out = digest.Markdown(d)
+	case "html":
+		out = digest.HTML(d)
+	case "text":
+		out = digest.Text(d)
+	default:
+		width := 0
+		if digestOut == "" && ui.IsTerminal(int(os.Stdout.Fd())) {
+			width = ui.TermWidth(int(os.Stdout.Fd()))
+		}
+		out = digest.Terminal(d, noColor || digestOut != "", width)
+	}
+
+	if digestOut == "" {
+		_, err := os.Stdout.WriteString(out)
+		return err
+	}
+	return os.WriteFile(digestOut, []byte(out), 0644)
+}
+
+// parseDigestSince resolves --since to a point in time. "last" falls back
+// to a day ago the first time.
+func parseDigestSince(value string, state *readstate.State, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "last" {
+		if last := state.LastRun(digestLastRunScope); !last.IsZero() {
+			return last, nil
+		}
+		return now.Add(-digestDefaultSince), nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, appErrors.NewUserError("invalid --since %q - use a duration (12h, 3d), a date (2026-10-15) or \"last\"", value)
+}
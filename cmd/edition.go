@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/browse"
+	"github.com/tmustier/economist-tui/internal/edition"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var (
+	editionJSON   bool
+	editionBrowse bool
+)
+
+var editionCmd = &cobra.Command{
+	Use:   "edition [date]",
+	Short: "Show the weekly print edition's table of contents",
+	Long: `Show the table of contents of a weekly print edition: its sections in
+print order and the articles in each.
+
+Without a date the latest issue is shown. A date picks the issue dated on
+or after that day (issues are dated Saturday).
+
+Examples:
+  economist edition
+  economist edition 2026-10-10
+  economist edition --json
+  economist edition --browse`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEdition,
+}
+
+func init() {
+	editionCmd.Flags().BoolVar(&editionJSON, "json", false, "Output JSON")
+	editionCmd.Flags().BoolVar(&editionBrowse, "browse", false, "Browse the issue section by section in the TUI")
+	rootCmd.AddCommand(editionCmd)
+}
+
+func runEdition(cmd *cobra.Command, args []string) error {
+	if editionJSON && editionBrowse {
+		return appErrors.NewUserError("--json and --browse are mutually exclusive")
+	}
+
+	dateArg := ""
+	if len(args) > 0 {
+		dateArg = args[0]
+	}
+	date, err := edition.ParseDate(dateArg)
+	if err != nil {
+		return err
+	}
+
+	ed, err := edition.Fetch(date)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case editionJSON:
+		return printEditionJSON(ed)
+	case editionBrowse:
+		if !ui.IsTerminal(int(os.Stdin.Fd())) {
+			return appErrors.NewUserError("browse requires an interactive terminal - use 'edition --json' for scripts")
+		}
+		source := edition.NewSource(ed, debugMode)
+		opts := browse.Options{Debug: debugMode, NoColor: noColor, Source: source}
+		return browse.Run(edition.SectionKey(ed.Sections[0].Name), opts)
+	}
+
+	printEdition(ed)
+	return nil
+}
+
+type editionOutput struct {
+	Date     string            `json:"date,omitempty"`
+	Title    string            `json:"title,omitempty"`
+	URL      string            `json:"url"`
+	Sections []edition.Section `json:"sections"`
+}
+
+func printEditionJSON(ed *edition.Edition) error {
+	out := editionOutput{Title: ed.Title, URL: ed.URL, Sections: ed.Sections}
+	if !ed.Date.IsZero() {
+		out.Date = ed.Date.Format("2006-01-02")
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(data, '\n'))
+	return err
+}
+
+func printEdition(ed *edition.Edition) {
+	termWidth := ui.TermWidth(int(os.Stdout.Fd()))
+	if termWidth <= 0 {
+		termWidth = ui.DefaultWidth
+	}
+	contentWidth := ui.ReaderContentWidth(termWidth)
+
+	styles := ui.NewBrowseStyles(noColor)
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), noColor)
+
+	title := "Weekly edition"
+	if !ed.Date.IsZero() {
+		title = fmt.Sprintf("Weekly edition · %s", ed.Date.Format("2 January 2006"))
+	}
+	fmt.Printf("%s\n", styles.Header.Render(title))
+	fmt.Printf("%s\n", ui.AccentRule(contentWidth, accentStyles))
+
+	total := ed.ArticleCount()
+	numWidth := len(fmt.Sprintf("%d", total))
+	prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, total))
+	prefixPad := strings.Repeat(" ", prefixWidth)
+	textWidth := ui.Max(ui.MinTitleWidth, contentWidth-prefixWidth)
+
+	n := 0
+	for _, section := range ed.Sections {
+		fmt.Printf("\n%s\n", styles.Header.Render(section.Name))
+		fmt.Printf("%s\n", ui.SectionRule(contentWidth, accentStyles))
+		for _, art := range section.Articles {
+			n++
+			for idx, line := range ui.WrapLines(art.Title, textWidth) {
+				prefix := prefixPad
+				if idx == 0 {
+					prefix = fmt.Sprintf("%*d. ", numWidth, n)
+				}
+				fmt.Printf("%s%s\n", styles.Title.Render(prefix), styles.Title.Render(line))
+			}
+			if art.Rubric != "" {
+				for _, line := range ui.WrapLines(art.Rubric, textWidth) {
+					fmt.Printf("%s%s\n", prefixPad, styles.Subtitle.Render(line))
+				}
+			}
+		}
+	}
+	fmt.Printf("\n%s\n", styles.Dim.Render(ed.URL))
+}
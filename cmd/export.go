@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	"github.com/tmustier/economist-tui/internal/epub"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/ui"
+	"github.com/tmustier/economist-tui/internal/vault"
+)
+
+var (
+	exportFormat    string
+	exportOut       string
+	exportTitle     string
+	exportBookmarks bool
+	exportTag       string
+	exportSection   string
+	exportLimit     int
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [url...]",
+	Short: "Export articles as an e-book, web page or Markdown notes",
+	Long: `Export articles as an EPUB 3 book for e-readers, one chapter per article
+with a table of contents, as a single standalone HTML page styled like the
+reader (--format html), or as Markdown files with YAML front matter for
+note-taking vaults (--format markdown, -o names the directory).
+
+Markdown files are named after the article's URL slug and carry bookmark
+tags. Exporting again rewrites the same files, leaving unchanged ones alone.
+
+Articles come from the URLs given (or listed on stdin), the bookmarks list,
+or the latest headlines of a section.
+
+Examples:
+  economist export https://www.economist.com/... -o article.epub
+  economist export <url> <url> <url> --title "Commute reading"
+  economist export --bookmarks --tag weekly -o weekly.epub
+  economist export --section leaders -n 8
+  economist export <url> --format html -o article.html
+  economist export --bookmarks --format markdown -o ~/vault/economist
+  economist headlines finance --plain | cut -f2 | economist export -`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "epub", "Output format: epub, html or markdown")
+	exportCmd.Flags().StringVarP(&exportOut, "output", "o", "", "Output file, or directory for markdown (default: derived from the title)")
+	exportCmd.Flags().StringVar(&exportTitle, "title", "", "Book title")
+	exportCmd.Flags().BoolVar(&exportBookmarks, "bookmarks", false, "Export the bookmarks list")
+	exportCmd.Flags().StringVarP(&exportTag, "tag", "t", "", "With --bookmarks, only bookmarks with this tag")
+	exportCmd.Flags().StringVarP(&exportSection, "section", "s", "", "Export the latest headlines of a section")
+	exportCmd.Flags().IntVarP(&exportLimit, "number", "n", 10, "With --section, number of articles")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	switch exportFormat {
+	case "epub", "html", "markdown":
+	default:
+		return appErrors.NewUserError("unknown format %q (use epub, html or markdown)", exportFormat)
+	}
+
+	urls, title, err := exportURLs(args)
+	if err != nil {
+		return err
+	}
+	if exportTitle != "" {
+		title = exportTitle
+	}
+
+	articles := fetchExportArticles(urls)
+	if len(articles) == 0 {
+		return appErrors.NewUserError("no articles could be fetched")
+	}
+	if exportFormat == "markdown" {
+		return exportMarkdown(articles)
+	}
+	if title == "" {
+		title = articles[0].Title
+	}
+
+	path := exportOut
+	if path == "" {
+		path = slugify(title) + "." + exportFormat
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if exportFormat == "html" {
+		_, err = file.WriteString(ui.ArticleHTML(title, articles))
+	} else {
+		err = epub.Write(file, epub.Book{Title: title, Articles: articles})
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d articles to %s\n", len(articles), path)
+	return nil
+}
+
+// exportURLs resolves what to export to article URLs, and a title for the
+// bundle when the source suggests one.
+func exportURLs(args []string) ([]string, string, error) {
+	sources := 0
+	for _, set := range []bool{len(args) > 0, exportBookmarks, exportSection != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return nil, "", appErrors.NewUserError("export URLs, --bookmarks or --section, not several")
+	}
+
+	switch {
+	case exportBookmarks:
+		list, err := bookmarks.List(exportTag)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(list) == 0 {
+			return nil, "", appErrors.NewUserError("no bookmarks to export")
+		}
+		urls := make([]string, 0, len(list))
+		for _, b := range list {
+			urls = append(urls, b.URL)
+		}
+		title := "Reading list"
+		if exportTag != "" {
+			title = fmt.Sprintf("Reading list: %s", exportTag)
+		}
+		return urls, title, nil
+	case exportSection != "":
+		feed, err := rss.FetchSection(exportSection)
+		if err != nil {
+			return nil, "", err
+		}
+		items := feed.Channel.Items
+		if exportLimit > 0 && len(items) > exportLimit {
+			items = items[:exportLimit]
+		}
+		urls := make([]string, 0, len(items))
+		for _, item := range items {
+			urls = append(urls, strings.TrimSpace(item.Link))
+		}
+		title := strings.TrimSpace(feed.Channel.Title)
+		if title == "" {
+			title = exportSection
+		}
+		return urls, fmt.Sprintf("%s, %s", title, time.Now().Format("2 Jan 2006")), nil
+	}
+
+	if len(args) == 0 || (len(args) == 1 && args[0] == "-") {
+		if !stdinHasData() {
+			return nil, "", appErrors.NewUserError("nothing to export - pass URLs, --bookmarks or --section")
+		}
+		data, err := readAllStdin()
+		if err != nil {
+			return nil, "", err
+		}
+		args = strings.Fields(data)
+	}
+	if len(args) == 0 {
+		return nil, "", appErrors.NewUserError("no URLs found on stdin")
+	}
+	if len(args) == 1 {
+		return args, "", nil
+	}
+	return args, fmt.Sprintf("The Economist, %s", time.Now().Format("2 Jan 2006")), nil
+}
+
+// exportMarkdown writes each article into the output directory, tagged
+// with its bookmark's tags.
+func exportMarkdown(articles []*article.Article) error {
+	dir := exportOut
+	if dir == "" {
+		dir = "."
+	}
+	counts := make(map[vault.Status]int)
+	for _, art := range articles {
+		var tags []string
+		if b, ok, err := bookmarks.Load(art.URL); err == nil && ok {
+			tags = b.Tags
+		}
+		path, status, err := vault.WriteArticle(dir, art, tags)
+		if err != nil {
+			return err
+		}
+		counts[status]++
+		fmt.Printf("%-9s %s\n", status, path)
+	}
+	fmt.Printf("Exported %d articles to %s (%d created, %d updated, %d unchanged)\n",
+		len(articles), dir, counts[vault.Created], counts[vault.Updated], counts[vault.Unchanged])
+	return nil
+}
+
+// fetchExportArticles fetches each article in turn. Articles that cannot be
+// fetched are reported and left out, so one paywall does not lose a bundle.
+func fetchExportArticles(urls []string) []*article.Article {
+	articles := make([]*article.Article, 0, len(urls))
+	for i, url := range urls {
+		fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", i+1, len(urls), url)
+		art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  skipped: %v\n", err)
+			continue
+		}
+		articles = append(articles, art)
+	}
+	return articles
+}
+
+// slugify turns a title into a file name, e.g. "Reading list: weekly" ->
+// "reading-list-weekly".
+func slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	if b.Len() == 0 {
+		return "economist"
+	}
+	return b.String()
+}
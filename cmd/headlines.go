@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/article"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
@@ -17,6 +22,10 @@ var (
 	headlinesSearch string
 	headlinesJSON   bool
 	headlinesPlain  bool
+	headlinesMeta   bool
+	headlinesAll    bool
+	headlinesUnread bool
+	headlinesNew    bool
 )
 
 var headlinesCmd = &cobra.Command{
@@ -24,20 +33,39 @@ var headlinesCmd = &cobra.Command{
 	Short: "Show latest headlines from a section",
 	Long: `Show latest headlines from The Economist RSS feeds.
 
+Searches with -s match words fuzzily in the title and description. They
+also accept "exact phrases", title:, desc: and section: fields,
+before:/after: dates (YYYY-MM-DD, YYYY-MM or YYYY), -word to exclude
+and OR between alternatives. Excluded words and words in a field match
+the start of a word rather than fuzzily.
+
+--unread hides headlines already opened in browse. --new-since-last-run
+shows only headlines published since the previous --new-since-last-run
+for the same section, then records this run.
+
 Examples:
   economist headlines leaders
   economist headlines finance -n 5
   economist headlines business -s "AI"
-  economist headlines finance --json`,
+  economist headlines china -s "after:2026-09-01 trade -tariffs"
+  economist headlines --all-sections -s "china trade"
+  economist headlines finance --unread
+  economist headlines leaders --new-since-last-run
+  economist headlines finance --json
+  economist headlines finance --json --metadata`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runHeadlines,
 }
 
 func init() {
 	headlinesCmd.Flags().IntVarP(&headlinesLimit, "number", "n", 10, "Number of headlines to show")
-	headlinesCmd.Flags().StringVarP(&headlinesSearch, "search", "s", "", "Search headlines for a term")
+	headlinesCmd.Flags().StringVarP(&headlinesSearch, "search", "s", "", "Filter headlines with a search query")
 	headlinesCmd.Flags().BoolVar(&headlinesJSON, "json", false, "Output JSON")
 	headlinesCmd.Flags().BoolVar(&headlinesPlain, "plain", false, "Output plain text (title\turl)")
+	headlinesCmd.Flags().BoolVar(&headlinesMeta, "metadata", false, "Fetch each article and include its metadata (with --json)")
+	headlinesCmd.Flags().BoolVar(&headlinesAll, "all-sections", false, "Search every section (with -s)")
+	headlinesCmd.Flags().BoolVar(&headlinesUnread, "unread", false, "Only show headlines not yet opened")
+	headlinesCmd.Flags().BoolVar(&headlinesNew, "new-since-last-run", false, "Only show headlines published since the last run with this flag")
 }
 
 func runHeadlines(cmd *cobra.Command, args []string) error {
@@ -49,11 +77,23 @@ func runHeadlines(cmd *cobra.Command, args []string) error {
 	if headlinesJSON && headlinesPlain {
 		return appErrors.NewUserError("--json and --plain are mutually exclusive")
 	}
+	if headlinesMeta && !headlinesJSON {
+		return appErrors.NewUserError("--metadata requires --json")
+	}
+	if headlinesAll && headlinesSearch == "" {
+		return appErrors.NewUserError("--all-sections requires -s <query>")
+	}
+	if headlinesAll && len(args) > 0 {
+		return appErrors.NewUserError("--all-sections searches every section - drop the section argument")
+	}
 
 	items, title, err := fetchHeadlines(section)
 	if err != nil {
 		return err
 	}
+	if headlinesUnread || headlinesNew {
+		items = filterReadState(items, section)
+	}
 
 	if headlinesJSON {
 		return printHeadlinesJSON(items, section)
@@ -68,6 +108,14 @@ func runHeadlines(cmd *cobra.Command, args []string) error {
 }
 
 func fetchHeadlines(section string) ([]rss.Item, string, error) {
+	if headlinesAll {
+		items, err := rss.SearchAll(headlinesSearch)
+		if err != nil {
+			return nil, "", err
+		}
+		title := fmt.Sprintf("Search: \"%s\" in all sections", headlinesSearch)
+		return items, title, nil
+	}
 	if headlinesSearch != "" {
 		items, err := rss.Search(section, headlinesSearch)
 		if err != nil {
@@ -85,6 +133,42 @@ func fetchHeadlines(section string) ([]rss.Item, string, error) {
 	return feed.Channel.Items, title, nil
 }
 
+// filterReadState applies --unread and --new-since-last-run. The run is
+// recorded even when nothing new turned up.
+func filterReadState(items []rss.Item, section string) []rss.Item {
+	state, err := readstate.Load()
+	if err != nil {
+		logging.Debugf(debugMode, "headlines: read state: %v", err)
+	}
+
+	scope := "headlines:" + rss.SectionPath(section)
+	if headlinesAll {
+		scope = "headlines:all"
+	}
+	since := state.LastRun(scope)
+
+	filtered := make([]rss.Item, 0, len(items))
+	for _, item := range items {
+		if headlinesUnread && state.IsRead(item.Key()) {
+			continue
+		}
+		if headlinesNew && !since.IsZero() {
+			if published, ok := item.Published(); !ok || !published.After(since) {
+				continue
+			}
+		}
+		filtered = append(filtered, item)
+	}
+
+	if headlinesNew {
+		state.SetLastRun(scope, time.Now())
+		if err := state.Save(); err != nil {
+			logging.Debugf(debugMode, "headlines: save read state: %v", err)
+		}
+	}
+	return filtered
+}
+
 func limitItems(items []rss.Item) []rss.Item {
 	if headlinesLimit > 0 && len(items) > headlinesLimit {
 		return items[:headlinesLimit]
@@ -93,26 +177,34 @@ func limitItems(items []rss.Item) []rss.Item {
 }
 
 type headlineOutput struct {
-	Title       string `json:"title"`
-	Description string `json:"description,omitempty"`
-	Date        string `json:"date"`
-	PubDate     string `json:"pub_date"`
-	URL         string `json:"url"`
-	Section     string `json:"section"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	Date        string            `json:"date"`
+	PubDate     string            `json:"pub_date"`
+	URL         string            `json:"url"`
+	Section     string            `json:"section"`
+	Metadata    *article.Metadata `json:"metadata,omitempty"`
 }
 
 func printHeadlinesJSON(items []rss.Item, section string) error {
 	items = limitItems(items)
 	out := make([]headlineOutput, 0, len(items))
 	for _, item := range items {
-		out = append(out, headlineOutput{
+		entry := headlineOutput{
 			Title:       item.CleanTitle(),
 			Description: item.CleanDescription(),
 			Date:        item.FormattedDate(),
 			PubDate:     item.PubDate,
 			URL:         item.Link,
 			Section:     section,
-		})
+		}
+		if item.Section != "" {
+			entry.Section = item.Section
+		}
+		if headlinesMeta {
+			entry.Metadata = fetchMetadata(item.Link)
+		}
+		out = append(out, entry)
 	}
 
 	data, err := json.Marshal(out)
@@ -123,6 +215,18 @@ func printHeadlinesJSON(items []rss.Item, section string) error {
 	return err
 }
 
+// fetchMetadata returns the article's metadata, or nil when it cannot be
+// fetched; one failing article should not hide the rest of the list.
+func fetchMetadata(url string) *article.Metadata {
+	art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
+	if err != nil {
+		logging.Debugf(debugMode, "headlines: metadata fetch error for %s: %v", url, err)
+		return nil
+	}
+	meta := art.Metadata()
+	return &meta
+}
+
 func printHeadlinesPlain(items []rss.Item) {
 	items = limitItems(items)
 	for _, item := range items {
@@ -159,7 +263,8 @@ func printHeadlines(items []rss.Item, title string) {
 
 	for i, item := range items {
 		num := fmt.Sprintf("%*d. ", numWidth, i+1)
-		headline := item.CleanTitle()
+		badge := ui.SectionLabel(item.Section)
+		headline := ui.BadgedTitle(badge, item.CleanTitle())
 		date := item.FormattedDate()
 		if dateLayout.Compact {
 			date = item.CompactDate()
@@ -173,8 +278,14 @@ func printHeadlines(items []rss.Item, title string) {
 		for idx, line := range titleLines {
 			if idx == 0 {
 				paddedTitle := fmt.Sprintf("%-*s", layout.TitleWidth, line)
-				fmt.Printf("%s%s%s\n",
+				styledBadge := ""
+				if badge != "" && strings.HasPrefix(paddedTitle, badge) {
+					styledBadge = styles.Badge.Render(badge)
+					paddedTitle = paddedTitle[len(badge):]
+				}
+				fmt.Printf("%s%s%s%s\n",
 					styles.Title.Render(num),
+					styledBadge,
 					styles.Title.Render(paddedTitle),
 					styles.Dim.Render(dateColumn),
 				)
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/history"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var (
+	historyLimit int
+	historyJSON  bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List articles read in the browse reader",
+	Long: `List the articles opened in the browse reader, most recent first, with
+how far each was read. Reopening an article in browse resumes where you
+left off, even on a different terminal size.
+
+Examples:
+  economist history
+  economist history -n 50
+  economist history --json`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "number", "n", 20, "Number of entries to show (0 = all)")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output JSON")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	entries, err := history.List()
+	if err != nil {
+		return err
+	}
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[:historyLimit]
+	}
+
+	if historyJSON {
+		return printHistoryJSON(entries)
+	}
+	printHistory(entries)
+	return nil
+}
+
+type historyOutput struct {
+	Title     string  `json:"title"`
+	URL       string  `json:"url"`
+	Section   string  `json:"section,omitempty"`
+	OpenedAt  string  `json:"opened_at"`
+	Scroll    float64 `json:"scroll"`
+	Completed bool    `json:"completed"`
+}
+
+func printHistoryJSON(entries []history.Entry) error {
+	out := make([]historyOutput, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, historyOutput{
+			Title:     entry.Title,
+			URL:       entry.URL,
+			Section:   entry.Section,
+			OpenedAt:  entry.OpenedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Scroll:    entry.Scroll,
+			Completed: entry.Completed,
+		})
+	}
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(data, '\n'))
+	return err
+}
+
+func printHistory(entries []history.Entry) {
+	if len(entries) == 0 {
+		fmt.Println("No reading history.")
+		return
+	}
+
+	styles := ui.NewBrowseStyles(noColor)
+	numWidth := len(fmt.Sprintf("%d", len(entries)))
+	for i, entry := range entries {
+		progress := "finished"
+		if !entry.Completed {
+			progress = fmt.Sprintf("%d%% read", int(math.Round(entry.Scroll*100)))
+		}
+		detail := fmt.Sprintf("%s · %s", entry.OpenedAt.Local().Format("2 Jan 2006 15:04"), progress)
+		if entry.Section != "" {
+			detail = entry.Section + " · " + detail
+		}
+		fmt.Printf("%s %s\n",
+			styles.Title.Render(fmt.Sprintf("%*d.", numWidth, i+1)),
+			styles.Title.Render(entry.Title),
+		)
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(detail))
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(entry.URL))
+	}
+}
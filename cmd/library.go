@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/browse"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/library"
+	"github.com/tmustier/economist-tui/internal/ui"
+	"github.com/tmustier/economist-tui/internal/vault"
+)
+
+var libraryJSON bool
+
+var libraryCmd = &cobra.Command{
+	Use:   "library",
+	Short: "Manage saved articles for offline reading",
+	Long: `Manage the offline library. Saved articles never expire.
+
+Save articles with 'economist read <url> --save' or 's' in the browse reader.
+Entries can be referred to by URL or by their number in 'library list'.
+
+Examples:
+  economist library list
+  economist library show 1
+  economist library search "bond restructuring"
+  economist library rm 3
+  economist library export ~/economist
+  economist library browse`,
+}
+
+var libraryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved articles",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := library.List()
+		if err != nil {
+			return err
+		}
+		return printLibraryEntries(entries)
+	},
+}
+
+var librarySearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search saved articles",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := library.Search(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+		return printLibraryEntries(entries)
+	},
+}
+
+var libraryShowCmd = &cobra.Command{
+	Use:   "show <url|number>",
+	Short: "Read a saved article",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkReadFormat(); err != nil {
+			return err
+		}
+		entry, err := library.Resolve(args[0])
+		if err != nil {
+			return err
+		}
+		return outputArticle(&entry.Article)
+	},
+}
+
+var libraryRmCmd = &cobra.Command{
+	Use:   "rm <url|number>...",
+	Short: "Remove saved articles",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Resolve every reference before removing any, so numbers refer
+		// to the list as it was when the command started.
+		urls := make([]string, 0, len(args))
+		for _, ref := range args {
+			entry, err := library.Resolve(ref)
+			if err != nil {
+				return err
+			}
+			urls = append(urls, entry.Article.URL)
+		}
+		for _, url := range urls {
+			if _, err := library.Remove(url); err != nil {
+				return err
+			}
+			fmt.Printf("Removed %s\n", url)
+		}
+		return nil
+	},
+}
+
+var libraryExportCmd = &cobra.Command{
+	Use:   "export <dir>",
+	Short: "Export saved articles as markdown files",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := library.List()
+		if err != nil {
+			return err
+		}
+		dir := args[0]
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			name := vault.FileName(entry.Article.URL) + ".md"
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(entry.Article.ToMarkdown()), 0644); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Exported %d articles to %s\n", len(entries), dir)
+		return nil
+	},
+}
+
+var libraryBrowseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Browse saved articles offline",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !ui.IsTerminal(int(os.Stdin.Fd())) {
+			return appErrors.NewUserError("browse requires an interactive terminal - use 'library list --json' for scripts")
+		}
+		source := library.NewSource(debugMode)
+		opts := browse.Options{Debug: debugMode, NoColor: noColor, Source: source}
+		return browse.Run(library.SectionName, opts)
+	},
+}
+
+func init() {
+	libraryListCmd.Flags().BoolVar(&libraryJSON, "json", false, "Output JSON")
+	librarySearchCmd.Flags().BoolVar(&libraryJSON, "json", false, "Output JSON")
+	libraryShowCmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw markdown")
+	libraryShowCmd.Flags().StringVar(&readFormat, "format", "", "Output format: markdown or html (default: rendered for the terminal)")
+	libraryShowCmd.Flags().IntVar(&wrapWidth, "wrap", 0, "Wrap width for rendered output (0 = auto)")
+
+	libraryCmd.AddCommand(libraryListCmd, librarySearchCmd, libraryShowCmd, libraryRmCmd, libraryExportCmd, libraryBrowseCmd)
+	rootCmd.AddCommand(libraryCmd)
+}
+
+type libraryOutput struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Section string `json:"section,omitempty"`
+	SavedAt string `json:"saved_at"`
+}
+
+func printLibraryEntries(entries []library.Entry) error {
+	if libraryJSON {
+		out := make([]libraryOutput, 0, len(entries))
+		for _, entry := range entries {
+			out = append(out, libraryOutput{
+				Title:   entry.Article.Title,
+				URL:     entry.Article.URL,
+				Section: entry.Article.Section,
+				SavedAt: entry.SavedAt.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		}
+		data, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(append(data, '\n'))
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No saved articles.")
+		return nil
+	}
+
+	styles := ui.NewBrowseStyles(noColor)
+	numWidth := len(fmt.Sprintf("%d", len(entries)))
+	for i, entry := range entries {
+		fmt.Printf("%s %s\n",
+			styles.Title.Render(fmt.Sprintf("%*d.", numWidth, i+1)),
+			styles.Title.Render(entry.Article.Title),
+		)
+		fmt.Printf("%*s %s\n", numWidth+1, "", styles.Dim.Render(
+			fmt.Sprintf("saved %s · %s", entry.SavedAt.Local().Format("2 Jan 2006"), entry.Article.URL),
+		))
+	}
+	return nil
+}
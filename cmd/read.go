@@ -12,38 +12,61 @@ import (
 	"github.com/tmustier/economist-tui/internal/config"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/library"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
 var (
-	rawOutput bool
-	wrapWidth int
-	columns   int
+	rawOutput  bool
+	readFormat string
+	wrapWidth  int
+	columns    int
+	saveRead   bool
+	readJobs   int
+	readOutDir string
+	readJSON   bool
 )
 
 var readCmd = &cobra.Command{
-	Use:   "read [url|-]",
+	Use:   "read [url...|-]",
 	Short: "Read an article",
 	Long: `Fetch and display a full article in the terminal.
 
+Several URLs (as arguments or one per line on stdin) are fetched
+concurrently and printed one after another, written one file per article
+with --output-dir, or streamed one JSON object per line with --json.
+A URL that fails is reported without stopping the rest.
+
+--json prints the article with its metadata and body, one object per
+article, in the versioned schema described in docs/article-json.md.
+
 Requires login first: economist login
 
 Examples:
   economist read https://www.economist.com/leaders/2026/01/15/some-article
   economist read <url> --raw
-  echo "https://www.economist.com/..." | economist read -`,
-	Args: cobra.RangeArgs(0, 1),
+  economist read <url> --json | jq -r .text
+  economist read <url> --format html > article.html
+  economist read <url> --save
+  echo "https://www.economist.com/..." | economist read -
+  economist headlines finance --json | jq -r '.[].url' | economist read - --json
+  economist read <url> <url> --output-dir notes --jobs 8`,
 	RunE: runRead,
 }
 
 func init() {
 	readCmd.Flags().BoolVar(&rawOutput, "raw", false, "Output raw markdown")
+	readCmd.Flags().StringVar(&readFormat, "format", "", "Output format: markdown or html (default: rendered for the terminal)")
+	readCmd.Flags().BoolVar(&readJSON, "json", false, "Output the article as JSON (one line per article)")
 	readCmd.Flags().IntVar(&wrapWidth, "wrap", 0, "Wrap width for rendered output (0 = auto)")
 	readCmd.Flags().IntVar(&columns, "columns", 1, "Number of columns for article body (1 or 2)")
+	readCmd.Flags().BoolVar(&saveRead, "save", false, "Save the article to the offline library")
+	readCmd.Flags().IntVarP(&readJobs, "jobs", "j", fetch.DefaultWorkers, "Articles to fetch at once when reading several")
+	readCmd.Flags().StringVar(&readOutDir, "output-dir", "", "Write each article to its own file in this directory")
 }
 
 func runRead(cmd *cobra.Command, args []string) error {
-	url, err := resolveURL(args)
+	urls, err := resolveURLs(args)
 	if err != nil {
 		return err
 	}
@@ -51,6 +74,18 @@ func runRead(cmd *cobra.Command, args []string) error {
 	if columns < 1 || columns > 2 {
 		return appErrors.NewUserError("columns must be 1 or 2")
 	}
+	if err := checkReadFormat(); err != nil {
+		return err
+	}
+	if readJSON {
+		if readFormat != "" || rawOutput {
+			return appErrors.NewUserError("--json cannot be combined with --format or --raw")
+		}
+		readFormat = "json"
+	}
+	if readJobs < 1 {
+		return appErrors.NewUserError("--jobs must be at least 1")
+	}
 
 	if !config.IsLoggedIn() {
 		fmt.Fprintln(os.Stderr, "⚠️  Not logged in. Run 'economist login' first.")
@@ -60,6 +95,11 @@ func runRead(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(os.Stderr, "")
 	}
 
+	if len(urls) > 1 || readOutDir != "" || readFormat == "json" {
+		return runReadBatch(urls)
+	}
+	url := urls[0]
+
 	art, err := fetch.FetchArticle(url, fetch.Options{Debug: debugMode})
 	if err != nil {
 		return err
@@ -69,10 +109,42 @@ func runRead(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Debug HTML saved to: %s\n", art.DebugHTMLPath)
 	}
 
+	if saveRead {
+		if err := library.Save(art); err != nil {
+			return fmt.Errorf("failed to save to library: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Saved to library")
+	}
+
 	return outputArticle(art)
 }
 
+func checkReadFormat() error {
+	switch readFormat {
+	case "", "markdown", "html":
+		return nil
+	}
+	return appErrors.NewUserError("unknown format %q (use markdown or html)", readFormat)
+}
+
 func outputArticle(art *article.Article) error {
+	out, err := renderArticle(art)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
+
+// renderArticle formats the article for stdout according to --format.
+func renderArticle(art *article.Article) (string, error) {
+	switch readFormat {
+	case "html":
+		return ui.ArticleHTML("", []*article.Article{art}), nil
+	case "markdown":
+		return art.ToMarkdown(), nil
+	}
+
 	opts := ui.ArticleRenderOptions{
 		Raw:       rawOutput,
 		NoColor:   noColor,
@@ -91,28 +163,34 @@ func outputArticle(art *article.Article) error {
 		}
 	}
 
-	out, err := ui.RenderArticle(art, opts)
-	if err != nil {
-		return err
-	}
-
-	fmt.Print(out)
-	return nil
+	return ui.RenderArticle(art, opts)
 }
 
-func resolveURL(args []string) (string, error) {
-	if len(args) == 1 && args[0] != "-" {
-		return args[0], nil
-	}
-
+// resolveURLs returns the URLs to read: the arguments, or every URL on
+// stdin when there are none or the only one is "-". Repeats are dropped.
+func resolveURLs(args []string) ([]string, error) {
 	if len(args) == 0 || (len(args) == 1 && args[0] == "-") {
 		if !stdinHasData() {
-			return "", appErrors.NewUserError("no URL provided - pass a URL or use stdin")
+			return nil, appErrors.NewUserError("no URL provided - pass a URL or use stdin")
+		}
+		var err error
+		if args, err = readURLsFromStdin(); err != nil {
+			return nil, err
 		}
-		return readURLFromStdin()
 	}
 
-	return "", appErrors.NewUserError("invalid arguments")
+	urls := make([]string, 0, len(args))
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		if arg == "-" {
+			return nil, appErrors.NewUserError("invalid arguments - use \"-\" on its own to read URLs from stdin")
+		}
+		if !seen[arg] {
+			seen[arg] = true
+			urls = append(urls, arg)
+		}
+	}
+	return urls, nil
 }
 
 func stdinHasData() bool {
@@ -123,14 +201,19 @@ func stdinHasData() bool {
 	return info.Mode()&os.ModeCharDevice == 0
 }
 
-func readURLFromStdin() (string, error) {
-	data, err := io.ReadAll(bufio.NewReader(os.Stdin))
+func readURLsFromStdin() ([]string, error) {
+	data, err := readAllStdin()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	fields := strings.Fields(string(data))
+	fields := strings.Fields(data)
 	if len(fields) == 0 {
-		return "", appErrors.NewUserError("no URL found on stdin")
+		return nil, appErrors.NewUserError("no URL found on stdin")
 	}
-	return fields[0], nil
+	return fields, nil
+}
+
+func readAllStdin() (string, error) {
+	data, err := io.ReadAll(bufio.NewReader(os.Stdin))
+	return string(data), err
 }
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/library"
+	"github.com/tmustier/economist-tui/internal/ui"
+	"github.com/tmustier/economist-tui/internal/vault"
+)
+
+// readErrorOutput is the line `read --json` writes in place of an
+// article that could not be read. It carries the payload version so every
+// line matches docs/article-json.md.
+type readErrorOutput struct {
+	Version int    `json:"version"`
+	URL     string `json:"url"`
+	Error   string `json:"error"`
+}
+
+// runReadBatch fetches urls concurrently and writes each article as it
+// arrives, in the order given. Failures are reported per URL and counted
+// in the returned error once the rest are done.
+func runReadBatch(urls []string) error {
+	if readOutDir != "" {
+		if err := os.MkdirAll(readOutDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	var (
+		failed   int
+		lastErr  error
+		htmlArts []*article.Article
+		written  = make(map[string]bool)
+		first    = true
+	)
+	for result := range fetch.FetchBatch(urls, readJobs, fetch.Options{Debug: debugMode}) {
+		if result.Err != nil {
+			failed++
+			lastErr = result.Err
+			if len(urls) > 1 {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", result.URL, result.Err)
+			}
+			if readOutDir == "" && readFormat == "json" {
+				if err := writeReadLine(result); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if saveRead {
+			if err := library.Save(result.Article); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: failed to save to library: %v\n", result.URL, err)
+			}
+		}
+
+		var err error
+		switch {
+		case readOutDir != "":
+			err = writeReadFile(result.Article, written)
+		case readFormat == "json":
+			err = writeReadLine(result)
+		case readFormat == "html":
+			htmlArts = append(htmlArts, result.Article)
+		default:
+			err = writeReadArticle(result.Article, first)
+			first = false
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(htmlArts) > 0 {
+		fmt.Print(ui.ArticleHTML("", htmlArts))
+	}
+	if failed > 0 {
+		if len(urls) == 1 {
+			return lastErr
+		}
+		return fmt.Errorf("%d of %d articles could not be read", failed, len(urls))
+	}
+	return nil
+}
+
+func writeReadLine(result fetch.Result) error {
+	var line any
+	if result.Err != nil {
+		line = readErrorOutput{Version: article.PayloadVersion, URL: result.URL, Error: result.Err.Error()}
+	} else {
+		line = article.NewPayload(result.Article)
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(data, '\n'))
+	return err
+}
+
+// writeReadArticle prints one article of a batch, separated from the one
+// before it.
+func writeReadArticle(art *article.Article, first bool) error {
+	out, err := renderArticle(art)
+	if err != nil {
+		return err
+	}
+	if !first {
+		if readFormat == "markdown" || rawOutput {
+			fmt.Print("\n---\n\n")
+		} else {
+			fmt.Print("\n\n")
+		}
+	}
+	fmt.Print(out)
+	return nil
+}
+
+// writeReadFile writes one article of a batch into --output-dir, as
+// Markdown unless --format says otherwise. Names are taken from the URL
+// slug, numbered when two articles share one.
+func writeReadFile(art *article.Article, written map[string]bool) error {
+	var content []byte
+	ext := ".md"
+	switch readFormat {
+	case "html":
+		ext = ".html"
+		content = []byte(ui.ArticleHTML("", []*article.Article{art}))
+	case "json":
+		ext = ".json"
+		data, err := json.Marshal(article.NewPayload(art))
+		if err != nil {
+			return err
+		}
+		content = append(data, '\n')
+	default:
+		content = []byte(art.ToMarkdown())
+	}
+
+	base := vault.FileName(art.SourceURL())
+	name := base + ext
+	for n := 2; written[name]; n++ {
+		name = fmt.Sprintf("%s-%d%s", base, n, ext)
+	}
+	written[name] = true
+
+	path := filepath.Join(readOutDir, name)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, path)
+	return nil
+}
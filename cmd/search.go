@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/search"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+var (
+	searchLimit int
+	searchJSON  bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search the text of articles you have read",
+	Long: `Search the full text of every article fetched so far.
+
+Articles are indexed as they are read, so the search covers anything opened
+with 'economist read' or in the browse TUI. Results are ranked by relevance;
+words are matched by stem ("restructured" finds "restructuring") and quoted
+phrases must appear as written.
+
+Examples:
+  economist search argentina bond restructuring
+  economist search '"central bank" independence'
+  economist search tariffs -n 5 --json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchLimit, "number", "n", 10, "Number of results to show")
+	searchCmd.Flags().BoolVar(&searchJSON, "json", false, "Output JSON")
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := strings.Join(args, " ")
+	ix, err := search.OpenIndex()
+	if err != nil {
+		return err
+	}
+	hits := ix.Search(query, searchLimit)
+
+	if searchJSON {
+		return printSearchJSON(hits)
+	}
+	printSearchHits(hits, query, ix.Len())
+	return nil
+}
+
+type searchOutput struct {
+	Title     string         `json:"title"`
+	URL       string         `json:"url"`
+	Section   string         `json:"section,omitempty"`
+	Published string         `json:"published,omitempty"`
+	Score     float64        `json:"score"`
+	Snippet   search.Snippet `json:"snippet"`
+}
+
+func printSearchJSON(hits []search.Hit) error {
+	out := make([]searchOutput, 0, len(hits))
+	for _, hit := range hits {
+		entry := searchOutput{
+			Title:   hit.Title,
+			URL:     hit.URL,
+			Section: hit.Section,
+			Score:   hit.Score,
+			Snippet: hit.Snippet,
+		}
+		if !hit.Published.IsZero() {
+			entry.Published = hit.Published.Format("2006-01-02T15:04:05Z07:00")
+		}
+		out = append(out, entry)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(append(data, '\n'))
+	return err
+}
+
+func printSearchHits(hits []search.Hit, query string, indexed int) {
+	termWidth := ui.TermWidth(int(os.Stdout.Fd()))
+	if termWidth <= 0 {
+		termWidth = ui.DefaultWidth
+	}
+	contentWidth := ui.ReaderContentWidth(termWidth)
+
+	styles := ui.NewBrowseStyles(noColor)
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), noColor)
+
+	fmt.Printf("%s\n", styles.Header.Render(fmt.Sprintf("Search: \"%s\"", query)))
+	fmt.Printf("%s\n\n", ui.AccentRule(contentWidth, accentStyles))
+
+	if indexed == 0 {
+		fmt.Println("No articles indexed yet - articles are indexed as you read them.")
+		return
+	}
+	if len(hits) == 0 {
+		fmt.Printf("No matches in %d indexed articles.\n", indexed)
+		return
+	}
+
+	numWidth := len(fmt.Sprintf("%d", len(hits)))
+	prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, len(hits)))
+	prefixPad := strings.Repeat(" ", prefixWidth)
+	textWidth := ui.Max(ui.MinTitleWidth, contentWidth-prefixWidth)
+
+	for i, hit := range hits {
+		for idx, line := range ui.WrapLines(hit.Title, textWidth) {
+			prefix := prefixPad
+			if idx == 0 {
+				prefix = fmt.Sprintf("%*d. ", numWidth, i+1)
+			}
+			fmt.Printf("%s%s\n", styles.Title.Render(prefix), styles.Title.Render(line))
+		}
+
+		var details []string
+		if hit.Section != "" {
+			details = append(details, hit.Section)
+		}
+		if !hit.Published.IsZero() {
+			details = append(details, hit.Published.Local().Format("2 Jan 2006"))
+		}
+		if len(details) > 0 {
+			fmt.Printf("%s%s\n", prefixPad, styles.Dim.Render(strings.Join(details, " · ")))
+		}
+
+		words := hit.Snippet.Words()
+		for _, line := range ui.WrapLines(hit.Snippet.Text, textWidth) {
+			fmt.Printf("%s%s\n", prefixPad, ui.HighlightWords(line, words, styles.Subtitle, styles.Match))
+		}
+
+		fmt.Printf("%s%s\n\n", prefixPad, styles.Dim.Render(hit.URL))
+	}
+}
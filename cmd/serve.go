@@ -4,16 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/tmustier/economist-tui/internal/browser"
 	"github.com/tmustier/economist-tui/internal/daemon"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 )
 
 var (
-	serveStatus bool
-	serveStop   bool
+	serveStatus  bool
+	serveStop    bool
+	serveTabs    int
+	serveTabUses int
+
+	servePrefetch      []string
+	servePrefetchCount int
+
+	serveIdleTimeout    time.Duration
+	serveMaxMemory      int
+	serveBrowserMaxTabs int
 )
 
 var serveCmd = &cobra.Command{
@@ -22,10 +33,27 @@ var serveCmd = &cobra.Command{
 	Long: `Run a local daemon that keeps a headless browser warm.
 
 The daemon listens on a local Unix socket and speeds up article reads.
+It fetches up to --tabs articles at once, each in a warm browser tab that
+is replaced after --tab-uses fetches.
+
+Browse asks it to prefetch the headline under the cursor. With
+--prefetch, each time browse or headlines refreshes one of those
+sections' feeds, the daemon also fetches the newest --prefetch-count
+unread articles into the cache, using only tabs that reads leave idle.
+No sections are prefetched by default, so a daemon started automatically
+by browse or read only fetches what it is asked for.
+
+The daemon exits after --idle-timeout without a read (0 keeps it running),
+and recycles Chrome once it holds more than --max-memory MB or has opened
+--max-browser-tabs tabs, without interrupting reads in progress. A daemon
+started automatically by browse or read uses the defaults.
 
 Examples:
   economist serve
   economist serve &
+  economist serve --tabs 8
+  economist serve --idle-timeout 0 --max-memory 512
+  economist serve --prefetch leaders,finance --prefetch-count 3
   economist serve --status
   economist serve --stop`,
 	RunE: runServe,
@@ -34,6 +62,13 @@ Examples:
 func init() {
 	serveCmd.Flags().BoolVar(&serveStatus, "status", false, "Show daemon status")
 	serveCmd.Flags().BoolVar(&serveStop, "stop", false, "Stop the daemon")
+	serveCmd.Flags().IntVar(&serveTabs, "tabs", browser.DefaultPoolSize, "Browser tabs, and so articles fetched at once")
+	serveCmd.Flags().IntVar(&serveTabUses, "tab-uses", browser.DefaultTabMaxUses, "Fetches before a tab is replaced")
+	serveCmd.Flags().StringSliceVar(&servePrefetch, "prefetch", nil, "Sections whose unread articles are prefetched when their feed is refreshed")
+	serveCmd.Flags().IntVar(&servePrefetchCount, "prefetch-count", daemon.DefaultPrefetchCount, "Unread articles prefetched per section (0 = off)")
+	serveCmd.Flags().DurationVar(&serveIdleTimeout, "idle-timeout", daemon.DefaultIdleTimeout, "Exit after this long without a request (0 = never)")
+	serveCmd.Flags().IntVar(&serveMaxMemory, "max-memory", daemon.DefaultMaxMemoryMB, "Recycle Chrome past this many MB resident (0 = no limit)")
+	serveCmd.Flags().IntVar(&serveBrowserMaxTabs, "max-browser-tabs", daemon.DefaultBrowserMaxTabs, "Recycle Chrome after it has opened this many tabs (0 = no limit)")
 	rootCmd.AddCommand(serveCmd)
 }
 
@@ -45,15 +80,18 @@ func runServe(cmd *cobra.Command, args []string) error {
 	if serveStatus {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
-		latency, running, err := daemon.Status(ctx)
-		if err != nil {
-			return err
-		}
-		if running {
-			fmt.Printf("running (%s)\n", latency)
-		} else {
+		health, latency, err := daemon.Inspect(ctx)
+		switch {
+		case errors.Is(err, daemon.ErrNotRunning):
 			fmt.Println("not running")
+			return nil
+		case errors.Is(err, daemon.ErrIncompatible):
+			fmt.Printf("%v; it is replaced on the next read\n", err)
+			return nil
+		case err != nil:
+			return err
 		}
+		printServeStatus(health, latency, time.Now())
 		return nil
 	}
 
@@ -71,6 +109,63 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if serveTabs < 1 || serveTabUses < 1 {
+		return appErrors.NewUserError("--tabs and --tab-uses must be at least 1")
+	}
+	if servePrefetchCount < 0 {
+		return appErrors.NewUserError("--prefetch-count must not be negative")
+	}
+	if serveIdleTimeout < 0 || serveMaxMemory < 0 || serveBrowserMaxTabs < 0 {
+		return appErrors.NewUserError("--idle-timeout, --max-memory and --max-browser-tabs must not be negative")
+	}
 	fmt.Println("Starting economist serve daemon...")
-	return daemon.Serve()
+	return daemon.Serve(daemon.ServeOptions{
+		Tabs:             serveTabs,
+		TabUses:          serveTabUses,
+		PrefetchSections: servePrefetch,
+		PrefetchCount:    servePrefetchCount,
+		Limits: daemon.Limits{
+			IdleTimeout:    serveIdleTimeout,
+			MaxMemoryMB:    serveMaxMemory,
+			BrowserMaxTabs: serveBrowserMaxTabs,
+		},
+	})
+}
+
+// printServeStatus describes a running daemon: how long it has been up,
+// when it was last used, and what it holds in memory.
+func printServeStatus(health daemon.Health, latency time.Duration, now time.Time) {
+	fmt.Printf("running (%s), pid %d\n", latency, health.PID)
+	if usage := health.Usage; usage != nil {
+		fmt.Printf("up %s\n", roundDuration(now.Sub(usage.StartedAt)))
+		if usage.LastRequestAt.IsZero() {
+			fmt.Println("no requests yet")
+		} else {
+			fmt.Printf("last request %s ago\n", roundDuration(now.Sub(usage.LastRequestAt)))
+		}
+		if usage.IdleTimeout > 0 {
+			fmt.Printf("exits after %s idle\n", roundDuration(time.Duration(usage.IdleTimeout)*time.Second))
+		} else {
+			fmt.Println("never exits idle")
+		}
+		if usage.Memory > 0 {
+			fmt.Printf("memory %d MB (Chrome %d MB)\n", usage.Memory>>20, usage.BrowserMemory>>20)
+		}
+	}
+	if tabs := health.Tabs; tabs != nil {
+		fmt.Printf("tabs %d open, %d busy; %d fetches served\n", tabs.Open, tabs.Busy, tabs.Served)
+		fmt.Printf("Chrome: %d restarts after a crash, %d recycles\n", tabs.Restarts, tabs.BrowserRecycles)
+		if tabs.Starting {
+			fmt.Println("Chrome is starting")
+		}
+	}
+}
+
+// roundDuration trims a duration to what a person reads: seconds under a
+// minute, minutes beyond.
+func roundDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
 }
@@ -0,0 +1,196 @@
+package annotations
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const annotationsDirName = "annotations"
+
+// Detached is the block of an annotation whose paragraph is no longer in
+// the article.
+const Detached = -1
+
+// Annotation is a highlight and/or note on one paragraph. Quote keeps the
+// paragraph text so the annotation can be found again if the article's
+// blocks shift.
+type Annotation struct {
+	Block     int       `json:"block"`
+	Quote     string    `json:"quote"`
+	Highlight bool      `json:"highlight"`
+	Note      string    `json:"note,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Empty reports whether the annotation carries nothing worth keeping.
+func (a Annotation) Empty() bool {
+	return !a.Highlight && strings.TrimSpace(a.Note) == ""
+}
+
+// Document holds every annotation on one article, in paragraph order.
+type Document struct {
+	URL         string       `json:"url"`
+	Title       string       `json:"title"`
+	Section     string       `json:"section,omitempty"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), annotationsDirName)
+}
+
+func documentPath(url string) string {
+	return filepath.Join(Dir(), store.KeyName(url))
+}
+
+// Load returns the annotations on the article, or an empty document for it
+// when there are none yet.
+func Load(art *article.Article) (*Document, error) {
+	doc := &Document{URL: art.URL, Title: art.Title, Section: art.Section}
+	if _, err := store.ReadJSON(documentPath(art.URL), doc); err != nil {
+		return doc, err
+	}
+	doc.Title = art.Title
+	if art.Section != "" {
+		doc.Section = art.Section
+	}
+	return doc, nil
+}
+
+// Save writes the document, or deletes it once it has no annotations.
+func (d *Document) Save() error {
+	if len(d.Annotations) == 0 {
+		_, err := store.Remove(documentPath(d.URL))
+		return err
+	}
+	return store.WriteJSON(documentPath(d.URL), d)
+}
+
+var (
+	queueMu sync.Mutex
+	queued  = make(map[string]Document)
+	// writeMu runs queued saves one at a time.
+	writeMu sync.Mutex
+)
+
+// Queue records the snapshot as the one to save for its article, replacing
+// any not yet written. Edits queue their snapshots in order and save them
+// with SaveQueued in the background.
+func Queue(d Document) {
+	queueMu.Lock()
+	defer queueMu.Unlock()
+	queued[d.URL] = d
+}
+
+// SaveQueued writes the latest queued snapshot of the article, if one is
+// still waiting. Since each save takes the newest snapshot, saves that
+// finish out of order never leave an older one on disk.
+func SaveQueued(url string) error {
+	writeMu.Lock()
+	defer writeMu.Unlock()
+
+	queueMu.Lock()
+	d, ok := queued[url]
+	delete(queued, url)
+	queueMu.Unlock()
+	if !ok {
+		return nil
+	}
+	return d.Save()
+}
+
+// Get returns the annotation on the block.
+func (d *Document) Get(block int) (Annotation, bool) {
+	for _, a := range d.Annotations {
+		if a.Block == block {
+			return a, true
+		}
+	}
+	return Annotation{}, false
+}
+
+// Set stores the annotation, replacing any on the same block, and drops it
+// when it is empty.
+func (d *Document) Set(a Annotation) {
+	kept := d.Annotations[:0]
+	for _, existing := range d.Annotations {
+		if existing.Block != a.Block {
+			kept = append(kept, existing)
+		}
+	}
+	d.Annotations = kept
+	if a.Empty() {
+		return
+	}
+	a.Note = strings.TrimSpace(a.Note)
+	a.UpdatedAt = time.Now().UTC()
+	d.Annotations = append(d.Annotations, a)
+	sort.SliceStable(d.Annotations, func(i, j int) bool {
+		return d.Annotations[i].Block < d.Annotations[j].Block
+	})
+}
+
+// Anchor moves annotations whose paragraph is no longer at its recorded
+// block to the block now holding the same text. Annotations whose text is
+// gone are detached: not drawn in the reader, but still exported.
+func (d *Document) Anchor(art *article.Article) {
+	for i, a := range d.Annotations {
+		if a.Block >= 0 && a.Block < len(art.Blocks) && art.Blocks[a.Block].PlainText() == a.Quote {
+			continue
+		}
+		d.Annotations[i].Block = Detached
+		for j, block := range art.Blocks {
+			if block.Kind == article.BlockParagraph && block.PlainText() == a.Quote {
+				d.Annotations[i].Block = j
+				break
+			}
+		}
+	}
+}
+
+// List returns every annotated article, sorted by title.
+func List() ([]Document, error) {
+	files, err := os.ReadDir(Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	docs := make([]Document, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		var doc Document
+		if ok, err := store.ReadJSON(filepath.Join(Dir(), file.Name()), &doc); err != nil || !ok {
+			continue
+		}
+		if len(doc.Annotations) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		return docs[i].Title < docs[j].Title
+	})
+	return docs, nil
+}
+
+// Lookup returns the annotations on the article at the URL.
+func Lookup(url string) (*Document, bool, error) {
+	var doc Document
+	ok, err := store.ReadJSON(documentPath(url), &doc)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return &doc, true, nil
+}
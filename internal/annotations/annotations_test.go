@@ -0,0 +1,122 @@
+package annotations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func setTempHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+}
+
+func testArticle() *article.Article {
+	return &article.Article{
+		URL:     "https://www.economist.com/finance/bonds",
+		Title:   "Bonds",
+		Section: "Finance & economics",
+		Blocks:  article.ParagraphBlocks("Yields rose.\n\nInvestors fled.\n\nCentral banks hesitated."),
+	}
+}
+
+func TestSetSaveAndLoad(t *testing.T) {
+	setTempHome(t)
+	art := testArticle()
+
+	doc, err := Load(art)
+	if err != nil || len(doc.Annotations) != 0 {
+		t.Fatalf("expected empty document, got %#v (err %v)", doc, err)
+	}
+	doc.Set(Annotation{Block: 2, Quote: "Central banks hesitated.", Note: "  compare with 2022  "})
+	doc.Set(Annotation{Block: 0, Quote: "Yields rose.", Highlight: true})
+	if err := doc.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load(art)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded.Annotations) != 2 || loaded.Annotations[0].Block != 0 || loaded.Annotations[1].Note != "compare with 2022" {
+		t.Fatalf("unexpected annotations: %#v", loaded.Annotations)
+	}
+
+	loaded.Set(Annotation{Block: 0})
+	loaded.Set(Annotation{Block: 2})
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("save empty: %v", err)
+	}
+	if _, ok, _ := Lookup(art.URL); ok {
+		t.Fatalf("expected cleared annotations to be removed")
+	}
+}
+
+func TestSaveQueuedKeepsTheLatestSnapshot(t *testing.T) {
+	setTempHome(t)
+	art := testArticle()
+
+	older, _ := Load(art)
+	older.Set(Annotation{Block: 0, Quote: "Yields rose.", Highlight: true})
+	Queue(*older)
+	newer := *older
+	newer.Annotations = append([]Annotation(nil), older.Annotations...)
+	newer.Set(Annotation{Block: 0, Quote: "Yields rose.", Highlight: true, Note: "later"})
+	Queue(newer)
+
+	// The save started for the newer edit runs first; the older edit's
+	// save then finds nothing left to write.
+	for i := 0; i < 2; i++ {
+		if err := SaveQueued(art.URL); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+	loaded, _, err := Lookup(art.URL)
+	if err != nil || len(loaded.Annotations) != 1 || loaded.Annotations[0].Note != "later" {
+		t.Fatalf("expected the newest snapshot on disk, got %#v (err %v)", loaded, err)
+	}
+}
+
+func TestAnchorFollowsMovedParagraphs(t *testing.T) {
+	art := testArticle()
+	doc := &Document{URL: art.URL, Annotations: []Annotation{
+		{Block: 1, Quote: "Investors fled.", Highlight: true},
+		{Block: 2, Quote: "A paragraph that was cut.", Note: "gone"},
+	}}
+
+	art.Blocks = append(article.ParagraphBlocks("A new standfirst."), art.Blocks...)
+	doc.Anchor(art)
+	if doc.Annotations[0].Block != 2 {
+		t.Fatalf("expected annotation to move to block 2, got %d", doc.Annotations[0].Block)
+	}
+	if doc.Annotations[1].Block != Detached {
+		t.Fatalf("expected missing paragraph to detach, got %d", doc.Annotations[1].Block)
+	}
+}
+
+func TestListAndMarkdownExport(t *testing.T) {
+	setTempHome(t)
+	art := testArticle()
+	doc, _ := Load(art)
+	doc.Set(Annotation{Block: 1, Quote: "Investors fled.", Highlight: true, Note: "Panic, or prudence?"})
+	if err := doc.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	docs, err := List()
+	if err != nil || len(docs) != 1 {
+		t.Fatalf("expected one annotated article, got %d (err %v)", len(docs), err)
+	}
+	md := ToMarkdown(docs)
+	for _, want := range []string{
+		"## [Bonds](https://www.economist.com/finance/bonds)",
+		"_Finance & economics_",
+		"> Investors fled.",
+		"Panic, or prudence?",
+	} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("expected %q in export:\n%s", want, md)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package annotations
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMarkdown renders the annotated articles as quotes with their notes,
+// one section per article.
+func ToMarkdown(docs []Document) string {
+	var b strings.Builder
+	b.WriteString("# Annotations\n")
+	for _, doc := range docs {
+		b.WriteString(fmt.Sprintf("\n## [%s](%s)\n", doc.Title, doc.URL))
+		if doc.Section != "" {
+			b.WriteString("\n_" + doc.Section + "_\n")
+		}
+		for _, a := range doc.Annotations {
+			b.WriteString("\n")
+			for _, line := range strings.Split(a.Quote, "\n") {
+				b.WriteString("> " + line + "\n")
+			}
+			if a.Note != "" {
+				b.WriteString("\n" + a.Note + "\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -3,7 +3,9 @@ package article
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +28,8 @@ const (
 	contentWaitTimeout   = 10 * time.Second // Wait for JS to load full article content
 	contentPollInterval  = 250 * time.Millisecond
 	contentMinParagraphs = 3 // Expect at least this many paragraphs in a full article
+
+	endMarker = "■" // closes every full Economist article
 )
 
 var blockedURLPatterns = []string{
@@ -63,13 +67,28 @@ type Article struct {
 	Title         string
 	Subtitle      string
 	DateLine      string
-	Content       string
+	Section       string
+	Byline        string
+	Published     time.Time
+	Modified      time.Time
+	FetchedAt     time.Time // when the page was fetched; kept through the cache
+	WordCount     int
+	ImageURL      string
+	CanonicalURL  string
+	Blocks        []Block
 	URL           string
 	DebugHTMLPath string
 }
 
 type FetchOptions struct {
 	Debug bool
+	// Tabs, when set, supplies a warm tab instead of opening one on the
+	// shared browser.
+	Tabs *browser.TabPool
+	// Context bounds the wait for a pooled tab, e.g. to the daemon request
+	// so a fetch the client gave up on leaves the queue. Nil waits as long
+	// as it takes.
+	Context context.Context
 }
 
 func Fetch(articleURL string, opts FetchOptions) (*Article, error) {
@@ -81,13 +100,51 @@ func Fetch(articleURL string, opts FetchOptions) (*Article, error) {
 }
 
 func FetchWithCookies(articleURL string, opts FetchOptions, cookies []config.Cookie) (*Article, error) {
-	start := time.Now()
+	art, lost, err := fetchOnce(articleURL, opts, cookies)
+	if lost {
+		// Chrome died under the fetch. The next tab comes from a relaunched
+		// browser, so the fetch gets one more go.
+		logging.Debugf(opts.Debug, "browser lost during fetch, retrying: %v", err)
+		art, _, err = fetchOnce(articleURL, opts, cookies)
+	}
+	return art, err
+}
 
-	baseCtx := browser.SharedHeadlessContext(opts.Debug)
-	ctx, cancel := chromedp.NewContext(baseCtx)
-	defer cancel()
+// fetchOnce fetches the article in a tab and reports whether a failure was
+// down to the browser dying.
+func fetchOnce(articleURL string, opts FetchOptions, cookies []config.Cookie) (*Article, bool, error) {
+	if opts.Tabs == nil {
+		ctx, cancel := chromedp.NewContext(browser.SharedHeadlessContext(opts.Debug))
+		defer cancel()
+		art, err := fetchInTab(ctx, articleURL, opts, cookies)
+		return art, err != nil && browser.Lost(ctx), err
+	}
 
-	ctx, cancel = context.WithTimeout(ctx, browser.FetchTimeout)
+	waitCtx := opts.Context
+	if waitCtx == nil {
+		waitCtx = context.Background()
+	}
+	tab, err := opts.Tabs.Acquire(waitCtx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get a browser tab: %w", err)
+	}
+	art, err := fetchInTab(tab.Context(), articleURL, opts, cookies)
+	lost := err != nil && tab.BrowserLost()
+	// A page that failed to load may have left the tab wedged; a paywall or
+	// parse error says nothing about the tab.
+	opts.Tabs.Release(tab, err != nil && art == nil)
+	return art, lost, err
+}
+
+// PrepareTab sets up a pooled tab once, before its first fetch.
+func PrepareTab(ctx context.Context) error {
+	return configureNetwork(ctx, false)
+}
+
+func fetchInTab(tabCtx context.Context, articleURL string, opts FetchOptions, cookies []config.Cookie) (*Article, error) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(tabCtx, browser.FetchTimeout)
 	defer cancel()
 
 	logging.Debugf(opts.Debug, "context ready in %s", time.Since(start))
@@ -95,8 +152,11 @@ func FetchWithCookies(articleURL string, opts FetchOptions, cookies []config.Coo
 	// Inject saved cookies (ignore errors - will just hit paywall)
 	_ = browser.InjectCookies(ctx, cookies)
 
-	if err := configureNetwork(ctx, opts.Debug); err != nil {
-		logging.Debugf(opts.Debug, "network blocking error: %v", err)
+	if opts.Tabs == nil {
+		// Pooled tabs are set up once, by PrepareTab.
+		if err := configureNetwork(ctx, opts.Debug); err != nil {
+			logging.Debugf(opts.Debug, "network blocking error: %v", err)
+		}
 	}
 
 	navStart := time.Now()
@@ -151,7 +211,22 @@ func parseArticle(html, articleURL string) (*Article, error) {
 		return nil, err
 	}
 
-	article := &Article{URL: articleURL}
+	meta := pageMetadata(doc)
+	article := &Article{
+		URL:          articleURL,
+		Title:        meta.Title,
+		Subtitle:     meta.Description,
+		Section:      meta.Section,
+		Byline:       meta.Byline,
+		Published:    meta.Published,
+		Modified:     meta.Modified,
+		WordCount:    meta.WordCount,
+		ImageURL:     meta.ImageURL,
+		CanonicalURL: meta.CanonicalURL,
+		FetchedAt:    time.Now().UTC(),
+	}
+
+	// Structured data is preferred; the selectors below only fill gaps.
 	article.Overtitle = findFirst(
 		doc,
 		".article__overline",
@@ -165,15 +240,37 @@ func parseArticle(html, articleURL string) (*Article, error) {
 	if article.Overtitle == "" {
 		article.Overtitle = extractHeaderOvertitle(doc)
 	}
-	article.Title = findFirst(doc, "h1.article__headline", "[data-test-id='headline']", "article h1", "h1")
-	article.Subtitle = findFirst(doc, ".article__description", "[data-test-id='subheadline']", ".article__subheadline", "h2.article__description", "header h2", "section h2")
+	if article.Overtitle == "" {
+		article.Overtitle = article.Section
+	}
+	if article.Title == "" {
+		article.Title = findFirst(doc, "h1.article__headline", "[data-test-id='headline']", "article h1", "h1")
+	}
+	if article.Subtitle == "" {
+		article.Subtitle = findFirst(doc, ".article__description", "[data-test-id='subheadline']", ".article__subheadline", "h2.article__description", "header h2", "section h2")
+	}
 	if article.Subtitle == "" {
 		article.Subtitle = extractFirstHeading(doc, "h2")
 	}
 	article.DateLine = strings.TrimSpace(doc.Find("time").First().Text())
-	article.Content = extractContent(doc)
+	if article.DateLine == "" && !article.Published.IsZero() {
+		article.DateLine = formatDateLine(article.Published)
+	}
+	if article.Published.IsZero() {
+		article.Published = jsonTime(doc.Find("time[datetime]").First().AttrOr("datetime", ""))
+	}
+	if article.Byline == "" {
+		article.Byline = findFirst(doc, "[data-test-id='byline']", ".article__byline", "[rel='author']")
+	}
+	if article.ImageURL == "" {
+		article.ImageURL = strings.TrimSpace(doc.Find("meta[property='og:image']").AttrOr("content", ""))
+	}
+	article.Blocks = extractBlocks(doc, articleURL)
+	if article.WordCount == 0 {
+		article.WordCount = len(strings.Fields(article.BodyText()))
+	}
 
-	if err := checkPaywall(html, article.Content); err != nil {
+	if err := checkPaywall(html, article.BodyText()); err != nil {
 		return article, err
 	}
 
@@ -241,39 +338,206 @@ func cleanHeaderText(text string) string {
 	return strings.Join(strings.Fields(text), " ")
 }
 
-func extractContent(doc *goquery.Document) string {
-	var paragraphs []string
+// bodyRootSelectors locate the container holding the article body. Legacy
+// layouts use explicit body containers; the current layout is handled by
+// findBodyRoot via data-component="paragraph".
+var bodyRootSelectors = []string{
+	"[data-component='article-body']",
+	".article__body-text",
+	".article__body",
+}
 
-	// Primary selectors for article body (current Economist HTML uses
-	// data-component="paragraph" on <p> elements; legacy layout used
-	// .article__body-text or [data-component='article-body'] containers).
-	doc.Find("p[data-component='paragraph'], .article__body-text p, [data-component='article-body'] p").Each(func(i int, s *goquery.Selection) {
-		if isInsideRelatedSection(s) {
-			return
-		}
-		if text := cleanParagraph(s); text != "" {
-			paragraphs = append(paragraphs, text)
-		}
-	})
+// bodyBlockSelector matches every element that can start a body block.
+// Matches nested inside a container block (a <p> inside a <blockquote>, a
+// <table> inside a <figure>) are folded into that container.
+const (
+	bodyBlockSelector      = "p, h2, h3, h4, blockquote, ul, ol, figure, table, hr"
+	containerBlockSelector = "blockquote, ul, ol, figure, table"
+)
+
+func extractBlocks(doc *goquery.Document, articleURL string) []Block {
+	base, _ := url.Parse(articleURL)
+
+	var blocks []Block
+	if root, strict := findBodyRoot(doc); root != nil {
+		blocks = collectBlocks(root, strict, base)
+	}
 
 	// Fallback to broader selectors
-	if len(paragraphs) == 0 {
+	if countParagraphs(blocks) == 0 {
+		blocks = nil
 		doc.Find("article p, main p").Each(func(i int, s *goquery.Selection) {
 			if text := cleanParagraph(s); text != "" && !looksLikeTeaser(text) {
-				paragraphs = append(paragraphs, text)
+				blocks = append(blocks, Block{Kind: BlockParagraph, Text: text})
 			}
 		})
 	}
 
-	content := strings.Join(paragraphs, "\n\n")
-	content = strings.TrimSpace(content)
-	return trimTrailingMarker(content)
+	// Prefer the embedded body when it holds more than the rendered DOM,
+	// as with server-rendered pages fetched without JavaScript.
+	if embedded := embeddedBlocks(doc, base); len(blocksText(embedded)) > len(blocksText(blocks)) {
+		blocks = embedded
+	}
+
+	return trimTrailingMarker(blocks)
+}
+
+func blocksText(blocks []Block) string {
+	return (&Article{Blocks: blocks}).BodyText()
+}
+
+// findBodyRoot returns the body container and whether only
+// data-component="paragraph" elements should count as paragraphs.
+func findBodyRoot(doc *goquery.Document) (*goquery.Selection, bool) {
+	for _, sel := range bodyRootSelectors {
+		if root := doc.Find(sel).First(); root.Length() > 0 {
+			return root, false
+		}
+	}
+
+	first := doc.Find("p[data-component='paragraph']").First()
+	if first.Length() == 0 {
+		return nil, false
+	}
+	for _, sel := range []string{"section", "article", "main"} {
+		if root := first.Closest(sel); root.Length() > 0 {
+			return root, true
+		}
+	}
+	return first.Parent(), true
+}
+
+func collectBlocks(root *goquery.Selection, strict bool, base *url.URL) []Block {
+	var blocks []Block
+	root.Find(bodyBlockSelector).Each(func(i int, s *goquery.Selection) {
+		if s.ParentsUntilSelection(root).Filter(containerBlockSelector).Length() > 0 {
+			return
+		}
+		if isInsideRelatedSection(s) {
+			return
+		}
+
+		switch node := goquery.NodeName(s); node {
+		case "p":
+			if strict {
+				if component, _ := s.Attr("data-component"); component != "paragraph" {
+					return
+				}
+			}
+			if block, ok := paragraphBlock(s, base); ok {
+				blocks = append(blocks, block)
+			}
+		case "h2", "h3", "h4":
+			// Headings before the first paragraph belong to the article header.
+			if countParagraphs(blocks) == 0 {
+				return
+			}
+			if text := cleanHeaderText(s.Text()); text != "" && !isBoilerplate(text) {
+				blocks = append(blocks, Block{Kind: BlockHeading, Text: text, Level: int(node[1] - '0')})
+			}
+		case "blockquote":
+			if text := cleanQuote(s); text != "" {
+				blocks = append(blocks, Block{Kind: BlockQuote, Text: text})
+			}
+		case "ul", "ol":
+			if items := listItems(s); len(items) > 0 {
+				blocks = append(blocks, Block{Kind: BlockList, Items: items, Ordered: node == "ol"})
+			}
+		case "figure":
+			if table := s.Find("table").First(); table.Length() > 0 {
+				if block, ok := tableBlock(table); ok {
+					blocks = append(blocks, block)
+				}
+			}
+			if caption := cleanHeaderText(s.Find("figcaption").Text()); caption != "" {
+				blocks = append(blocks, Block{Kind: BlockFigure, Text: caption})
+			}
+		case "table":
+			if block, ok := tableBlock(s); ok {
+				blocks = append(blocks, block)
+			}
+		case "hr":
+			if len(blocks) > 0 && blocks[len(blocks)-1].Kind != BlockRule {
+				blocks = append(blocks, Block{Kind: BlockRule})
+			}
+		}
+	})
+	return blocks
+}
+
+func countParagraphs(blocks []Block) int {
+	count := 0
+	for _, block := range blocks {
+		if block.Kind == BlockParagraph {
+			count++
+		}
+	}
+	return count
+}
+
+func cleanQuote(s *goquery.Selection) string {
+	var lines []string
+	s.Find("p").Each(func(i int, p *goquery.Selection) {
+		if text := cleanHeaderText(p.Text()); text != "" {
+			lines = append(lines, text)
+		}
+	})
+	if len(lines) == 0 {
+		return cleanHeaderText(s.Text())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func listItems(s *goquery.Selection) []string {
+	var items []string
+	s.ChildrenFiltered("li").Each(func(i int, li *goquery.Selection) {
+		if text := cleanHeaderText(li.Text()); text != "" && !isBoilerplate(text) {
+			items = append(items, text)
+		}
+	})
+	return items
+}
+
+func tableBlock(s *goquery.Selection) (Block, bool) {
+	var rows [][]string
+	header := false
+	s.Find("tr").Each(func(i int, tr *goquery.Selection) {
+		var row []string
+		tr.ChildrenFiltered("th, td").Each(func(j int, cell *goquery.Selection) {
+			row = append(row, cleanHeaderText(cell.Text()))
+		})
+		if len(row) == 0 {
+			return
+		}
+		if len(rows) == 0 && tr.ChildrenFiltered("td").Length() == 0 {
+			header = true
+		}
+		rows = append(rows, row)
+	})
+	if len(rows) == 0 {
+		return Block{}, false
+	}
+	return Block{Kind: BlockTable, Rows: rows, Header: header}, true
 }
 
 func isInsideRelatedSection(s *goquery.Selection) bool {
 	return s.ParentsFiltered("[class*='related'], [class*='teaser'], [class*='promo']").Length() > 0
 }
 
+// paragraphBlock builds a paragraph block, keeping inline links as spans.
+func paragraphBlock(s *goquery.Selection, base *url.URL) (Block, bool) {
+	spans := collectSpans(s, base)
+	text := spansText(spans)
+	if len(text) < minParagraphLen || isBoilerplate(text) {
+		return Block{}, false
+	}
+	block := Block{Kind: BlockParagraph, Text: text}
+	if hasLinks(spans) {
+		block.Spans = spans
+	}
+	return block, true
+}
+
 func cleanParagraph(s *goquery.Selection) string {
 	text := strings.TrimSpace(s.Text())
 	if len(text) < minParagraphLen || isBoilerplate(text) {
@@ -311,33 +575,35 @@ func isBoilerplate(text string) bool {
 	return false
 }
 
-func trimTrailingMarker(content string) string {
-	const marker = "■"
-	if !strings.Contains(content, marker) {
-		return content
-	}
-
-	paragraphs := strings.Split(content, "\n\n")
-	if len(paragraphs) == 0 {
-		return content
-	}
-
-	start := len(paragraphs) - 3
-	if start < 0 {
-		start = 0
-	}
+// trimTrailingMarker cuts the body at the end-of-article marker, dropping
+// anything that follows it within the last few paragraphs.
+func trimTrailingMarker(blocks []Block) []Block {
+	const (
+		marker   = endMarker
+		maxScans = 3
+	)
 
-	for i := len(paragraphs) - 1; i >= start; i-- {
-		idx := strings.LastIndex(paragraphs[i], marker)
+	scanned := 0
+	for i := len(blocks) - 1; i >= 0 && scanned < maxScans; i-- {
+		if blocks[i].Kind != BlockParagraph {
+			continue
+		}
+		scanned++
+		idx := strings.LastIndex(blocks[i].Text, marker)
 		if idx == -1 {
 			continue
 		}
 
-		paragraphs[i] = strings.TrimSpace(paragraphs[i][:idx+len(marker)])
-		return strings.TrimSpace(strings.Join(paragraphs[:i+1], "\n\n"))
+		keep := idx + len(marker)
+		trimmed := append([]Block(nil), blocks[:i+1]...)
+		trimmed[i].Text = trimmed[i].Text[:keep]
+		if len(trimmed[i].Spans) > 0 {
+			trimmed[i].Spans = truncateSpans(trimmed[i].Spans, keep)
+		}
+		return trimmed
 	}
 
-	return content
+	return blocks
 }
 
 var paywallIndicators = []string{
@@ -490,11 +756,47 @@ func (a *Article) ToMarkdown() string {
 		sb.WriteString(fmt.Sprintf("%s\n\n", a.DateLine))
 	}
 
+	if byline := a.BylineLine(); byline != "" {
+		sb.WriteString(fmt.Sprintf("%s\n\n", byline))
+	}
+
 	sb.WriteString("---\n\n")
-	sb.WriteString(a.Content)
+	sb.WriteString(a.BodyMarkdown())
 	sb.WriteString("\n\n---\n")
-	sb.WriteString(a.URL)
+	sb.WriteString(a.SourceURL())
 	sb.WriteString("\n")
 
 	return sb.String()
 }
+
+// SourceURL returns the canonical URL when known, else the fetched URL.
+func (a *Article) SourceURL() string {
+	if a.CanonicalURL != "" {
+		return a.CanonicalURL
+	}
+	return a.URL
+}
+
+// BylineLine joins the author, section and word count for an article
+// header, e.g. "By Jane Doe · Finance & economics · 1,024 words".
+func (a *Article) BylineLine() string {
+	var parts []string
+	if a.Byline != "" {
+		parts = append(parts, "By "+strings.TrimPrefix(a.Byline, "By "))
+	}
+	if a.Section != "" && a.Section != a.Overtitle {
+		parts = append(parts, a.Section)
+	}
+	if a.WordCount > 0 {
+		parts = append(parts, formatCount(a.WordCount)+" words")
+	}
+	return strings.Join(parts, " · ")
+}
+
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -42,17 +42,18 @@ func TestParseArticleExtractsFields(t *testing.T) {
 		t.Fatalf("expected url, got %q", art.URL)
 	}
 
-	if !strings.Contains(art.Content, "First paragraph") {
-		t.Fatalf("expected first paragraph, got %q", art.Content)
+	content := art.BodyText()
+	if !strings.Contains(content, "First paragraph") {
+		t.Fatalf("expected first paragraph, got %q", content)
 	}
-	if !strings.Contains(art.Content, "Second paragraph ends with a marker ■") {
-		t.Fatalf("expected second paragraph, got %q", art.Content)
+	if !strings.Contains(content, "Second paragraph ends with a marker ■") {
+		t.Fatalf("expected second paragraph, got %q", content)
 	}
-	if strings.Contains(art.Content, "extra text that should be removed") {
-		t.Fatalf("expected trailing text removed, got %q", art.Content)
+	if strings.Contains(content, "extra text that should be removed") {
+		t.Fatalf("expected trailing text removed, got %q", content)
 	}
-	if !strings.HasSuffix(strings.TrimSpace(art.Content), "■") {
-		t.Fatalf("expected trailing marker, got %q", art.Content)
+	if !strings.HasSuffix(strings.TrimSpace(content), "■") {
+		t.Fatalf("expected trailing marker, got %q", content)
 	}
 }
 
@@ -66,17 +67,18 @@ func TestParseArticleModernSelectors(t *testing.T) {
 	if art.Title != "A modern headline" {
 		t.Fatalf("expected title, got %q", art.Title)
 	}
-	if !strings.Contains(art.Content, "First paragraph") {
-		t.Fatalf("expected first paragraph, got %q", art.Content)
+	content := art.BodyText()
+	if !strings.Contains(content, "First paragraph") {
+		t.Fatalf("expected first paragraph, got %q", content)
 	}
-	if !strings.Contains(art.Content, "Second paragraph") {
-		t.Fatalf("expected second paragraph, got %q", art.Content)
+	if !strings.Contains(content, "Second paragraph") {
+		t.Fatalf("expected second paragraph, got %q", content)
 	}
-	if !strings.Contains(art.Content, "Third paragraph") {
-		t.Fatalf("expected third paragraph, got %q", art.Content)
+	if !strings.Contains(content, "Third paragraph") {
+		t.Fatalf("expected third paragraph, got %q", content)
 	}
-	if !strings.HasSuffix(strings.TrimSpace(art.Content), "■") {
-		t.Fatalf("expected trailing marker, got %q", art.Content)
+	if !strings.HasSuffix(strings.TrimSpace(content), "■") {
+		t.Fatalf("expected trailing marker, got %q", content)
 	}
 }
 
@@ -90,3 +92,200 @@ func TestParseArticlePaywall(t *testing.T) {
 		t.Fatalf("expected paywall error, got %v", err)
 	}
 }
+
+func TestParseArticleStructuredBlocks(t *testing.T) {
+	html := loadFixture(t, "structured.html")
+	art, err := parseArticle(html, "https://example.com/structured")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	kinds := make([]BlockKind, 0, len(art.Blocks))
+	for _, block := range art.Blocks {
+		kinds = append(kinds, block.Kind)
+	}
+	expected := []BlockKind{
+		BlockParagraph,
+		BlockHeading,
+		BlockParagraph,
+		BlockQuote,
+		BlockList,
+		BlockTable,
+		BlockFigure,
+		BlockRule,
+		BlockHeading,
+		BlockParagraph,
+	}
+	if len(kinds) != len(expected) {
+		t.Fatalf("expected %d blocks, got %d: %v", len(expected), len(kinds), kinds)
+	}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Fatalf("block %d: expected %s, got %s (%v)", i, expected[i], kinds[i], kinds)
+		}
+	}
+
+	if art.Blocks[1].Text != "The first crosshead" || art.Blocks[1].Level != 2 {
+		t.Fatalf("unexpected crosshead: %#v", art.Blocks[1])
+	}
+	if art.Blocks[3].Text != "A quoted line from a minister.\nAnd its second line." {
+		t.Fatalf("unexpected quote: %q", art.Blocks[3].Text)
+	}
+	if len(art.Blocks[4].Items) != 2 || art.Blocks[4].Ordered {
+		t.Fatalf("unexpected list: %#v", art.Blocks[4])
+	}
+	if !art.Blocks[5].Header || len(art.Blocks[5].Rows) != 2 {
+		t.Fatalf("unexpected table: %#v", art.Blocks[5])
+	}
+	if art.Blocks[8].Level != 3 {
+		t.Fatalf("expected level 3 heading, got %d", art.Blocks[8].Level)
+	}
+	last := art.Blocks[len(art.Blocks)-1].Text
+	if !strings.HasSuffix(last, "■") {
+		t.Fatalf("expected trailing marker, got %q", last)
+	}
+}
+
+func TestParseArticleKeepsLinks(t *testing.T) {
+	html := loadFixture(t, "structured.html")
+	art, err := parseArticle(html, "https://www.economist.com/briefing/2026/03/26/structured")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	para := art.Blocks[2]
+	if spansText(para.Spans) != para.Text {
+		t.Fatalf("spans do not match text: %q vs %q", spansText(para.Spans), para.Text)
+	}
+
+	links := art.Links()
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links, got %#v", links)
+	}
+	if links[0].URL != "https://www.economist.com/finance-and-economics/2026/03/20/rates" || links[0].Text != "crosshead" {
+		t.Fatalf("unexpected first link: %#v", links[0])
+	}
+	if !IsEconomistURL(links[0].URL) || IsEconomistURL(links[1].URL) {
+		t.Fatalf("unexpected link hosts: %#v", links)
+	}
+
+	md := para.Markdown()
+	if !strings.Contains(md, "[extractor](https://example.org/extractor)") {
+		t.Fatalf("expected markdown link, got %q", md)
+	}
+}
+
+func TestArticleBodyMarkdown(t *testing.T) {
+	art := &Article{Blocks: []Block{
+		{Kind: BlockParagraph, Text: "Intro."},
+		{Kind: BlockHeading, Text: "Crosshead", Level: 2},
+		{Kind: BlockQuote, Text: "One\nTwo"},
+		{Kind: BlockList, Items: []string{"a", "b"}, Ordered: true},
+		{Kind: BlockTable, Rows: [][]string{{"A", "B"}, {"1", "2|3"}}, Header: true},
+		{Kind: BlockRule},
+	}}
+
+	expected := strings.Join([]string{
+		"Intro.",
+		"## Crosshead",
+		"> One\n> Two",
+		"1. a\n2. b",
+		"| A | B |\n| --- | --- |\n| 1 | 2\\|3 |",
+		"* * *",
+	}, "\n\n")
+	if got := art.BodyMarkdown(); got != expected {
+		t.Fatalf("unexpected markdown:\n%s", got)
+	}
+}
+
+func TestParseArticleUsesNextData(t *testing.T) {
+	html := loadFixture(t, "nextdata.html")
+	art, err := parseArticle(html, "https://www.economist.com/briefing/2026/03/26/server")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	if got := countParagraphs(art.Blocks); got != 4 {
+		t.Fatalf("expected 4 paragraphs from __NEXT_DATA__, got %d: %#v", got, art.Blocks)
+	}
+	if art.Blocks[2].Kind != BlockHeading || art.Blocks[2].Text != "A crosshead" {
+		t.Fatalf("expected crosshead, got %#v", art.Blocks[2])
+	}
+	links := art.Links()
+	if len(links) != 1 || links[0].URL != "https://www.economist.com/leaders/2026/03/19/earlier" {
+		t.Fatalf("unexpected links: %#v", links)
+	}
+	if !looksComplete(html, art) {
+		t.Fatalf("expected embedded body to count as complete (%d chars)", len(art.BodyText()))
+	}
+}
+
+func TestParseArticleUsesJSONLD(t *testing.T) {
+	html := loadFixture(t, "jsonld.html")
+	art, err := parseArticle(html, "https://www.economist.com/leaders/2026/03/26/jsonld")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	if got := countParagraphs(art.Blocks); got != 2 {
+		t.Fatalf("expected 2 paragraphs from JSON-LD, got %d", got)
+	}
+	if !strings.HasPrefix(art.Blocks[1].Text, "The second paragraph of the JSON-LD body") {
+		t.Fatalf("unexpected second paragraph: %q", art.Blocks[1].Text)
+	}
+}
+
+func TestParseArticleJSONLDMetadata(t *testing.T) {
+	html := loadFixture(t, "jsonld.html")
+	art, err := parseArticle(html, "https://www.economist.com/leaders/2026/03/26/jsonld?ref=x")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	if art.Title != "A JSON-LD headline" {
+		t.Fatalf("expected JSON-LD headline over markup, got %q", art.Title)
+	}
+	if art.Subtitle != "A JSON-LD description" || art.Section != "Leaders" {
+		t.Fatalf("unexpected description/section: %q / %q", art.Subtitle, art.Section)
+	}
+	if art.Byline != "Jane Doe, John Roe" {
+		t.Fatalf("unexpected byline: %q", art.Byline)
+	}
+	if art.Published.Format("2006-01-02") != "2026-03-26" || art.Modified.Format("2006-01-02") != "2026-03-27" {
+		t.Fatalf("unexpected dates: %v / %v", art.Published, art.Modified)
+	}
+	if art.DateLine != "Mar 26th 2026" {
+		t.Fatalf("expected date line from datePublished, got %q", art.DateLine)
+	}
+	if art.WordCount != 1234 || art.ImageURL != "https://www.economist.com/img/lead.jpg" {
+		t.Fatalf("unexpected word count/image: %d / %q", art.WordCount, art.ImageURL)
+	}
+	if art.SourceURL() != "https://www.economist.com/leaders/2026/03/26/jsonld" {
+		t.Fatalf("unexpected canonical url: %q", art.SourceURL())
+	}
+	if !strings.Contains(art.ToMarkdown(), "By Jane Doe, John Roe · 1,234 words") {
+		t.Fatalf("expected byline in markdown:\n%s", art.ToMarkdown())
+	}
+}
+
+func TestParseArticleNextDataMetadata(t *testing.T) {
+	html := loadFixture(t, "nextdata.html")
+	art, err := parseArticle(html, "https://www.economist.com/briefing/2026/03/26/server")
+	if err != nil {
+		t.Fatalf("parse article: %v", err)
+	}
+
+	meta := art.Metadata()
+	if meta.Description != "The rubric from the payload" || meta.Section != "Briefing" {
+		t.Fatalf("unexpected description/section: %#v", meta)
+	}
+	if meta.Byline != "Our Buenos Aires correspondent" || meta.Published.IsZero() {
+		t.Fatalf("unexpected byline/published: %#v", meta)
+	}
+	if meta.ImageURL != "https://www.economist.com/img/main.jpg" || meta.CanonicalURL != "https://www.economist.com/briefing/2026/03/26/server" {
+		t.Fatalf("unexpected image/canonical: %#v", meta)
+	}
+	if meta.WordCount == 0 {
+		t.Fatalf("expected word count computed from the body")
+	}
+}
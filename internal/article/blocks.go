@@ -0,0 +1,198 @@
+package article
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlockKind identifies the type of a body block.
+type BlockKind string
+
+const (
+	BlockParagraph BlockKind = "paragraph"
+	BlockHeading   BlockKind = "heading"
+	BlockQuote     BlockKind = "quote"
+	BlockList      BlockKind = "list"
+	BlockFigure    BlockKind = "figure"
+	BlockTable     BlockKind = "table"
+	BlockRule      BlockKind = "rule"
+)
+
+// Block is one structural element of an article body.
+//
+// Text holds the plain text for paragraphs, headings, quotes and figure
+// captions. Paragraphs containing links also carry Spans, whose texts
+// concatenate to Text. Lists use Items, tables use Rows (the first row is
+// the header when Header is set).
+type Block struct {
+	Kind    BlockKind  `json:"kind"`
+	Text    string     `json:"text,omitempty"`
+	Spans   []Span     `json:"spans,omitempty"`
+	Level   int        `json:"level,omitempty"`
+	Items   []string   `json:"items,omitempty"`
+	Ordered bool       `json:"ordered,omitempty"`
+	Rows    [][]string `json:"rows,omitempty"`
+	Header  bool       `json:"header,omitempty"`
+}
+
+// ParagraphBlocks splits text on blank lines into paragraph blocks.
+func ParagraphBlocks(text string) []Block {
+	var blocks []Block
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		blocks = append(blocks, Block{Kind: BlockParagraph, Text: para})
+	}
+	return blocks
+}
+
+// HasBody reports whether the article has any body text.
+func (a *Article) HasBody() bool {
+	for _, block := range a.Blocks {
+		if block.Kind == BlockRule {
+			continue
+		}
+		if block.Text != "" || len(block.Items) > 0 || len(block.Rows) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BodyText returns the body as plain text, one block per paragraph.
+func (a *Article) BodyText() string {
+	parts := make([]string, 0, len(a.Blocks))
+	for _, block := range a.Blocks {
+		if text := block.PlainText(); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// BodyMarkdown returns the body as markdown.
+func (a *Article) BodyMarkdown() string {
+	parts := make([]string, 0, len(a.Blocks))
+	for _, block := range a.Blocks {
+		if md := block.Markdown(); md != "" {
+			parts = append(parts, md)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// PlainText renders the block without markup. List items and table rows
+// are placed on their own lines.
+func (b Block) PlainText() string {
+	switch b.Kind {
+	case BlockList:
+		lines := make([]string, 0, len(b.Items))
+		for i, item := range b.Items {
+			lines = append(lines, listMarker(b.Ordered, i)+item)
+		}
+		return strings.Join(lines, "\n")
+	case BlockTable:
+		lines := make([]string, 0, len(b.Rows))
+		for _, row := range b.Rows {
+			lines = append(lines, strings.Join(row, " │ "))
+		}
+		return strings.Join(lines, "\n")
+	case BlockRule:
+		return "* * *"
+	default:
+		return b.Text
+	}
+}
+
+// Markdown renders the block as markdown.
+func (b Block) Markdown() string {
+	switch b.Kind {
+	case BlockHeading:
+		level := b.Level
+		if level < 2 {
+			level = 2
+		}
+		if level > 6 {
+			level = 6
+		}
+		return strings.Repeat("#", level) + " " + b.Text
+	case BlockQuote:
+		lines := strings.Split(b.Text, "\n")
+		for i, line := range lines {
+			lines[i] = "> " + line
+		}
+		return strings.Join(lines, "\n")
+	case BlockList:
+		lines := make([]string, 0, len(b.Items))
+		for i, item := range b.Items {
+			marker := "- "
+			if b.Ordered {
+				marker = fmt.Sprintf("%d. ", i+1)
+			}
+			lines = append(lines, marker+item)
+		}
+		return strings.Join(lines, "\n")
+	case BlockFigure:
+		if b.Text == "" {
+			return ""
+		}
+		return "*" + b.Text + "*"
+	case BlockTable:
+		return tableMarkdown(b.Rows, b.Header)
+	case BlockRule:
+		return "* * *"
+	default:
+		if len(b.Spans) > 0 {
+			return spansMarkdown(b.Spans)
+		}
+		return b.Text
+	}
+}
+
+func listMarker(ordered bool, index int) string {
+	if ordered {
+		return fmt.Sprintf("%d. ", index+1)
+	}
+	return "• "
+}
+
+func tableMarkdown(rows [][]string, header bool) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	formatRow := func(row []string) string {
+		cells := make([]string, columns)
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = strings.ReplaceAll(row[i], "|", "\\|")
+			}
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+
+	var lines []string
+	body := rows
+	if header {
+		lines = append(lines, formatRow(rows[0]))
+		body = rows[1:]
+	} else {
+		lines = append(lines, formatRow(make([]string, columns)))
+	}
+	lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+	for _, row := range body {
+		lines = append(lines, formatRow(row))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,179 @@
+package article
+
+import (
+	"encoding/json"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Server-rendered pages carry the article in script tags as well as the
+// DOM. Without JavaScript the DOM often holds only the teaser paragraph,
+// while __NEXT_DATA__ and JSON-LD still have the full body.
+const (
+	nextDataSelector = "script#__NEXT_DATA__"
+	jsonLDSelector   = "script[type='application/ld+json']"
+)
+
+// embeddedBlocks extracts the body from __NEXT_DATA__, falling back to the
+// JSON-LD articleBody. It returns nil when neither is present.
+func embeddedBlocks(doc *goquery.Document, base *url.URL) []Block {
+	if blocks := nextDataBlocks(doc, base); countParagraphs(blocks) > 0 {
+		return blocks
+	}
+	return jsonLDBlocks(doc)
+}
+
+func nextDataBlocks(doc *goquery.Document, base *url.URL) []Block {
+	script := doc.Find(nextDataSelector).First()
+	if script.Length() == 0 {
+		return nil
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+		return nil
+	}
+
+	body := findJSONBody(data)
+	if body == "" {
+		return nil
+	}
+
+	// Re-parse the body as HTML so it goes through the same block
+	// extraction as the page itself.
+	bodyDoc, err := goquery.NewDocumentFromReader(strings.NewReader(
+		"<div data-component=\"article-body\">" + body + "</div>",
+	))
+	if err != nil {
+		return nil
+	}
+	return collectBlocks(bodyDoc.Find("[data-component='article-body']").First(), false, base)
+}
+
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true, "wbr": true}
+
+// findJSONBody searches for the first "body" field holding either an HTML
+// string or a parsed HTML node tree, and returns it as HTML.
+func findJSONBody(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		if body, ok := v["body"]; ok {
+			switch b := body.(type) {
+			case string:
+				if strings.Contains(b, "<p") {
+					return b
+				}
+			case []any:
+				if rendered := renderJSONNodes(b); strings.Contains(rendered, "<p") {
+					return rendered
+				}
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			if found := findJSONBody(v[key]); found != "" {
+				return found
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if found := findJSONBody(child); found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
+
+// renderJSONNodes renders a node tree of the form
+// {"type": "tag", "name": "p", "attribs": {...}, "children": [...]} and
+// {"type": "text", "data": "..."} back into HTML.
+func renderJSONNodes(nodes []any) string {
+	var sb strings.Builder
+	var render func([]any)
+	render = func(nodes []any) {
+		for _, raw := range nodes {
+			node, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch node["type"] {
+			case "text":
+				text, _ := node["data"].(string)
+				sb.WriteString(html.EscapeString(text))
+			case "tag":
+				name, _ := node["name"].(string)
+				if name == "" || name == "script" || name == "style" {
+					continue
+				}
+				sb.WriteString("<" + name)
+				if attribs, ok := node["attribs"].(map[string]any); ok {
+					for _, key := range sortedKeys(attribs) {
+						if s, ok := attribs[key].(string); ok {
+							sb.WriteString(" " + key + "=\"" + html.EscapeString(s) + "\"")
+						}
+					}
+				}
+				sb.WriteString(">")
+				if voidElements[name] {
+					continue
+				}
+				if children, ok := node["children"].([]any); ok {
+					render(children)
+				}
+				sb.WriteString("</" + name + ">")
+			}
+		}
+	}
+	render(nodes)
+	return sb.String()
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonLDBlocks(doc *goquery.Document) []Block {
+	var blocks []Block
+	doc.Find(jsonLDSelector).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		if body := findArticleBody(data); body != "" {
+			blocks = ParagraphBlocks(body)
+			return false
+		}
+		return true
+	})
+	return blocks
+}
+
+// findArticleBody returns the first articleBody string, looking through
+// arrays and @graph lists.
+func findArticleBody(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		if body, ok := v["articleBody"].(string); ok && strings.TrimSpace(body) != "" {
+			return body
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findArticleBody(graph)
+		}
+	case []any:
+		for _, child := range v {
+			if body := findArticleBody(child); body != "" {
+				return body
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package article
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FrontMatter returns YAML front matter describing the article, for note
+// apps that read metadata from the top of Markdown files. Empty fields are
+// left out.
+func (a *Article) FrontMatter(tags []string) string {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	field := func(key, value string) {
+		if value != "" {
+			sb.WriteString(key + ": " + strconv.Quote(value) + "\n")
+		}
+	}
+	field("title", a.Title)
+	field("subtitle", a.Subtitle)
+	field("overtitle", a.Overtitle)
+	field("section", a.Section)
+	if !a.Published.IsZero() {
+		sb.WriteString("published: " + a.Published.Format("2006-01-02") + "\n")
+	}
+	field("url", a.SourceURL())
+	if !a.FetchedAt.IsZero() {
+		sb.WriteString("fetched: " + a.FetchedAt.UTC().Format(time.RFC3339) + "\n")
+	}
+	if len(tags) > 0 {
+		sb.WriteString("tags:\n")
+		for _, tag := range tags {
+			sb.WriteString("  - " + strconv.Quote(tag) + "\n")
+		}
+	}
+	sb.WriteString("---\n\n")
+	return sb.String()
+}
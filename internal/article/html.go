@@ -0,0 +1,103 @@
+package article
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// BodyHTML returns the body as HTML fragments, one element per block. The
+// markup is also well-formed XHTML.
+func (a *Article) BodyHTML() string {
+	parts := make([]string, 0, len(a.Blocks))
+	for _, block := range a.Blocks {
+		if out := block.HTML(); out != "" {
+			parts = append(parts, out)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// HTML renders the block as an HTML element.
+func (b Block) HTML() string {
+	switch b.Kind {
+	case BlockHeading:
+		level := b.Level
+		if level < 2 {
+			level = 2
+		}
+		if level > 6 {
+			level = 6
+		}
+		return fmt.Sprintf("<h%d>%s</h%d>", level, html.EscapeString(b.Text), level)
+	case BlockQuote:
+		lines := strings.Split(b.Text, "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(line)
+		}
+		return "<blockquote><p>" + strings.Join(lines, "<br/>") + "</p></blockquote>"
+	case BlockList:
+		tag := "ul"
+		if b.Ordered {
+			tag = "ol"
+		}
+		var sb strings.Builder
+		sb.WriteString("<" + tag + ">")
+		for _, item := range b.Items {
+			sb.WriteString("<li>" + html.EscapeString(item) + "</li>")
+		}
+		sb.WriteString("</" + tag + ">")
+		return sb.String()
+	case BlockFigure:
+		if b.Text == "" {
+			return ""
+		}
+		return "<figure><figcaption>" + html.EscapeString(b.Text) + "</figcaption></figure>"
+	case BlockTable:
+		return tableHTML(b.Rows, b.Header)
+	case BlockRule:
+		return "<hr/>"
+	default:
+		if b.Text == "" {
+			return ""
+		}
+		if len(b.Spans) > 0 {
+			return "<p>" + spansHTML(b.Spans) + "</p>"
+		}
+		return "<p>" + html.EscapeString(b.Text) + "</p>"
+	}
+}
+
+func spansHTML(spans []Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		text := html.EscapeString(span.Text)
+		if span.URL == "" {
+			sb.WriteString(text)
+			continue
+		}
+		fmt.Fprintf(&sb, `<a href="%s">%s</a>`, html.EscapeString(span.URL), text)
+	}
+	return sb.String()
+}
+
+func tableHTML(rows [][]string, header bool) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("<table>")
+	for i, row := range rows {
+		cell := "td"
+		if header && i == 0 {
+			cell = "th"
+		}
+		sb.WriteString("<tr>")
+		for _, text := range row {
+			sb.WriteString("<" + cell + ">" + html.EscapeString(text) + "</" + cell + ">")
+		}
+		sb.WriteString("</tr>")
+	}
+	sb.WriteString("</table>")
+	return sb.String()
+}
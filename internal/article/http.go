@@ -0,0 +1,170 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/logging"
+)
+
+const (
+	// httpFetchTimeout is short: when the plain fetch does not pay off, the
+	// browser fetch still has to run after it.
+	httpFetchTimeout = 4 * time.Second
+	maxHTMLBytes     = 8 << 20
+
+	// wordCountTolerance is the share of the page's declared word count a
+	// body needs to be trusted as the whole article.
+	wordCountTolerance = 0.9
+)
+
+// ErrIncomplete means a plain HTTP fetch returned less than a full article,
+// so the page needs a browser to run its JavaScript.
+var ErrIncomplete = errors.New("article body incomplete")
+
+var regwallMarkers = []string{
+	"teg-inline-wall",
+	"data-testid=\"regwall\"",
+	"id=\"tp-regwall\"",
+}
+
+// FetchHTTPWithCookies fetches the article with a plain GET, sending the
+// saved cookies, and parses the server-rendered HTML. It returns
+// ErrIncomplete alongside the partial article when the body looks cut
+// short, and a PaywallError for subscriber stubs.
+func FetchHTTPWithCookies(articleURL string, opts FetchOptions, cookies []config.Cookie) (*Article, error) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), httpFetchTimeout)
+	defer cancel()
+
+	html, err := getHTML(ctx, articleURL, cookies)
+	if err != nil {
+		return nil, err
+	}
+	logging.Debugf(opts.Debug, "http: page loaded in %s (%d bytes)", time.Since(start), len(html))
+
+	art, parseErr := parseArticle(html, articleURL)
+	if opts.Debug {
+		if path, err := writeDebugHTML(html); err == nil {
+			if art == nil {
+				art = &Article{URL: articleURL}
+			}
+			art.DebugHTMLPath = path
+		}
+	}
+	if parseErr != nil {
+		return art, parseErr
+	}
+	if !looksComplete(html, art) {
+		logging.Debugf(opts.Debug, "http: body incomplete (%d paragraphs)", countParagraphs(art.Blocks))
+		return art, ErrIncomplete
+	}
+
+	logging.Debugf(opts.Debug, "http: total fetch time %s", time.Since(start))
+	return art, nil
+}
+
+// looksComplete reports whether the parsed body is a full article rather
+// than the teaser shown before the client-side auth check. A long teaser
+// is easily mistaken for an article, so the body is only trusted on a
+// positive sign: the closing marker, a page marked free to read, or a
+// word count that matches the page's metadata.
+func looksComplete(html string, art *Article) bool {
+	body := art.BodyText()
+	if len(body) < minContentLen {
+		return false
+	}
+	for _, marker := range regwallMarkers {
+		if strings.Contains(html, marker) && countParagraphs(art.Blocks) < contentMinParagraphs {
+			return false
+		}
+	}
+	if strings.HasSuffix(strings.TrimSpace(body), endMarker) {
+		return true
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return false
+	}
+	if accessibleForFree(doc) {
+		return true
+	}
+	declared := pageMetadata(doc).WordCount
+	return declared > 0 && float64(len(strings.Fields(body))) >= wordCountTolerance*float64(declared)
+}
+
+func getHTML(ctx context.Context, articleURL string, cookies []config.Cookie) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, articleURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", browser.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("Accept-Language", "en-GB,en;q=0.9")
+
+	jar, err := cookieJar(req.URL, cookies)
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{Jar: jar}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to load page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d from %s", resp.StatusCode, articleURL)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTMLBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read page: %w", err)
+	}
+	return string(body), nil
+}
+
+// cookieJar loads the saved cookies into a jar so they follow redirects
+// within the same domains, as the browser would send them.
+func cookieJar(target *url.URL, cookies []config.Cookie) (http.CookieJar, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byDomain := make(map[string][]*http.Cookie)
+	for _, c := range cookies {
+		domain := strings.TrimPrefix(c.Domain, ".")
+		if domain == "" {
+			domain = target.Hostname()
+		}
+		path := c.Path
+		if path == "" {
+			path = "/"
+		}
+		byDomain[domain] = append(byDomain[domain], &http.Cookie{
+			Name:   c.Name,
+			Value:  c.Value,
+			Domain: c.Domain,
+			Path:   path,
+		})
+	}
+
+	for domain, list := range byDomain {
+		jar.SetCookies(&url.URL{Scheme: target.Scheme, Host: domain, Path: "/"}, list)
+	}
+	return jar, nil
+}
@@ -0,0 +1,100 @@
+package article
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/config"
+)
+
+func TestFetchHTTPWithCookiesSendsCookies(t *testing.T) {
+	html := loadFixture(t, "nextdata.html")
+	var gotCookie string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie("session"); err == nil {
+			gotCookie = c.Value
+		}
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	cookies := []config.Cookie{{Name: "session", Value: "abc", Path: "/"}}
+	art, err := FetchHTTPWithCookies(server.URL+"/briefing/2026/03/26/server", FetchOptions{}, cookies)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if gotCookie != "abc" {
+		t.Fatalf("expected session cookie, got %q", gotCookie)
+	}
+	if art.Title != "A server-rendered headline" {
+		t.Fatalf("unexpected title: %q", art.Title)
+	}
+}
+
+func TestFetchHTTPWithCookiesReportsTeaser(t *testing.T) {
+	html := loadFixture(t, "modern.html")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	art, err := FetchHTTPWithCookies(server.URL+"/leaders/modern", FetchOptions{}, nil)
+	if !errors.Is(err, ErrIncomplete) {
+		t.Fatalf("expected ErrIncomplete, got %v", err)
+	}
+	if art == nil || !art.HasBody() {
+		t.Fatalf("expected the partial article alongside the error")
+	}
+}
+
+func TestFetchHTTPWithCookiesStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if _, err := FetchHTTPWithCookies(server.URL, FetchOptions{}, nil); err == nil {
+		t.Fatalf("expected error for HTTP 403")
+	}
+}
+
+func TestLooksCompleteNeedsPositiveSign(t *testing.T) {
+	paragraph := strings.Repeat("A long server-rendered teaser sentence. ", 6)
+	page := func(head, last string) string {
+		return fmt.Sprintf(`<html><head>%s</head><body><article>
+<p data-component="paragraph">%s</p>
+<p data-component="paragraph">%s</p>
+<p data-component="paragraph">%s%s</p>
+</article></body></html>`, head, paragraph, paragraph, paragraph, last)
+	}
+	ld := func(fields string) string {
+		return `<script type="application/ld+json">{"@type": "NewsArticle", "headline": "Teaser", ` + fields + `}</script>`
+	}
+
+	cases := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{"long teaser", page("", ""), false},
+		{"closing marker", page("", " ■"), true},
+		{"free to read", page(ld(`"isAccessibleForFree": true`), ""), true},
+		{"free as a string", page(ld(`"isAccessibleForFree": "True"`), ""), true},
+		{"paywalled", page(ld(`"isAccessibleForFree": false`), ""), false},
+		{"word count matches", page(ld(`"wordCount": 100`), ""), true},
+		{"word count short", page(ld(`"wordCount": 1200`), ""), false},
+	}
+	for _, tc := range cases {
+		art, err := parseArticle(tc.html, "https://www.economist.com/leaders/teaser")
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tc.name, err)
+		}
+		if got := looksComplete(tc.html, art); got != tc.want {
+			t.Errorf("%s: expected complete=%v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package article
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Span is a run of paragraph text. Linked runs carry the absolute URL.
+type Span struct {
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Link is a hyperlink found in the article body.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Links returns the body's hyperlinks in reading order, one per URL.
+func (a *Article) Links() []Link {
+	seen := make(map[string]bool)
+	var links []Link
+	for _, block := range a.Blocks {
+		for _, span := range block.Spans {
+			if span.URL == "" || seen[span.URL] {
+				continue
+			}
+			seen[span.URL] = true
+			links = append(links, Link{Text: cleanHeaderText(span.Text), URL: span.URL})
+		}
+	}
+	return links
+}
+
+// IsEconomistURL reports whether the URL points at economist.com.
+func IsEconomistURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return host == "economist.com" || strings.HasSuffix(host, ".economist.com")
+}
+
+// collectSpans flattens the selection's text, keeping <a href> runs as
+// linked spans. Relative links are resolved against base.
+func collectSpans(s *goquery.Selection, base *url.URL) []Span {
+	var spans []Span
+	appendSpan := func(text, link string) {
+		if text == "" {
+			return
+		}
+		if n := len(spans); n > 0 && spans[n-1].URL == link {
+			spans[n-1].Text += text
+			return
+		}
+		spans = append(spans, Span{Text: text, URL: link})
+	}
+
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			switch child.Type {
+			case html.TextNode:
+				appendSpan(child.Data, "")
+			case html.ElementNode:
+				switch child.Data {
+				case "script", "style":
+					continue
+				case "a":
+					sel := goquery.NewDocumentFromNode(child).Selection
+					appendSpan(sel.Text(), resolveLink(sel.AttrOr("href", ""), base))
+					continue
+				}
+				walk(child)
+			}
+		}
+	}
+	for _, node := range s.Nodes {
+		walk(node)
+	}
+
+	return trimSpans(spans)
+}
+
+func resolveLink(href string, base *url.URL) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return u.String()
+}
+
+func trimSpans(spans []Span) []Span {
+	for len(spans) > 0 {
+		spans[0].Text = strings.TrimLeftFunc(spans[0].Text, unicode.IsSpace)
+		if spans[0].Text != "" {
+			break
+		}
+		spans = spans[1:]
+	}
+	for len(spans) > 0 {
+		last := len(spans) - 1
+		spans[last].Text = strings.TrimRightFunc(spans[last].Text, unicode.IsSpace)
+		if spans[last].Text != "" {
+			break
+		}
+		spans = spans[:last]
+	}
+	return spans
+}
+
+func spansText(spans []Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
+
+func hasLinks(spans []Span) bool {
+	for _, span := range spans {
+		if span.URL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateSpans keeps the first n bytes of the spans' combined text.
+func truncateSpans(spans []Span, n int) []Span {
+	var out []Span
+	for _, span := range spans {
+		if n <= 0 {
+			break
+		}
+		if len(span.Text) > n {
+			span.Text = span.Text[:n]
+		}
+		n -= len(span.Text)
+		out = append(out, span)
+	}
+	return out
+}
+
+func spansMarkdown(spans []Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		if span.URL == "" {
+			sb.WriteString(span.Text)
+			continue
+		}
+		sb.WriteString("[")
+		sb.WriteString(span.Text)
+		sb.WriteString("](")
+		sb.WriteString(span.URL)
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
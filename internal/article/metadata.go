@@ -0,0 +1,328 @@
+package article
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Metadata is the article's descriptive data, without the body.
+type Metadata struct {
+	Title        string    `json:"title"`
+	Description  string    `json:"description,omitempty"`
+	Section      string    `json:"section,omitempty"`
+	Byline       string    `json:"byline,omitempty"`
+	Published    time.Time `json:"published,omitzero"`
+	Modified     time.Time `json:"modified,omitzero"`
+	WordCount    int       `json:"word_count,omitempty"`
+	ImageURL     string    `json:"image_url,omitempty"`
+	CanonicalURL string    `json:"canonical_url,omitempty"`
+}
+
+// Metadata returns the article's metadata.
+func (a *Article) Metadata() Metadata {
+	return Metadata{
+		Title:        a.Title,
+		Description:  a.Subtitle,
+		Section:      a.Section,
+		Byline:       a.Byline,
+		Published:    a.Published,
+		Modified:     a.Modified,
+		WordCount:    a.WordCount,
+		ImageURL:     a.ImageURL,
+		CanonicalURL: a.CanonicalURL,
+	}
+}
+
+var articleLDTypes = map[string]bool{
+	"NewsArticle":         true,
+	"Article":             true,
+	"ReportageNews":       true,
+	"AnalysisNewsArticle": true,
+	"OpinionNewsArticle":  true,
+}
+
+// pageMetadata reads metadata from the page's structured data. JSON-LD
+// wins over the Next.js payload; fields missing from both stay empty so
+// the selector cascade in parseArticle can fill them.
+func pageMetadata(doc *goquery.Document) Metadata {
+	meta := jsonLDMetadata(doc)
+	meta = mergeMetadata(meta, nextDataMetadata(doc))
+	if meta.CanonicalURL == "" {
+		meta.CanonicalURL = strings.TrimSpace(doc.Find("link[rel='canonical']").AttrOr("href", ""))
+	}
+	return meta
+}
+
+// mergeMetadata fills the empty fields of meta from fallback.
+func mergeMetadata(meta, fallback Metadata) Metadata {
+	if meta.Title == "" {
+		meta.Title = fallback.Title
+	}
+	if meta.Description == "" {
+		meta.Description = fallback.Description
+	}
+	if meta.Section == "" {
+		meta.Section = fallback.Section
+	}
+	if meta.Byline == "" {
+		meta.Byline = fallback.Byline
+	}
+	if meta.Published.IsZero() {
+		meta.Published = fallback.Published
+	}
+	if meta.Modified.IsZero() {
+		meta.Modified = fallback.Modified
+	}
+	if meta.WordCount == 0 {
+		meta.WordCount = fallback.WordCount
+	}
+	if meta.ImageURL == "" {
+		meta.ImageURL = fallback.ImageURL
+	}
+	if meta.CanonicalURL == "" {
+		meta.CanonicalURL = fallback.CanonicalURL
+	}
+	return meta
+}
+
+func jsonLDMetadata(doc *goquery.Document) Metadata {
+	var meta Metadata
+	doc.Find(jsonLDSelector).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		if obj := findLDArticle(data); obj != nil {
+			meta = metadataFromJSON(obj)
+			return false
+		}
+		return true
+	})
+	return meta
+}
+
+// accessibleForFree reports whether the page's JSON-LD marks the article
+// as free to read, in which case the server renders all of it.
+func accessibleForFree(doc *goquery.Document) bool {
+	free := false
+	doc.Find(jsonLDSelector).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		obj := findLDArticle(data)
+		if obj == nil {
+			return true
+		}
+		switch v := obj["isAccessibleForFree"].(type) {
+		case bool:
+			free = v
+		case string:
+			free = strings.EqualFold(v, "true")
+		}
+		return false
+	})
+	return free
+}
+
+// findLDArticle returns the first JSON-LD object typed as an article,
+// looking through arrays and @graph lists.
+func findLDArticle(value any) map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		if isLDArticle(v["@type"]) {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findLDArticle(graph)
+		}
+	case []any:
+		for _, child := range v {
+			if obj := findLDArticle(child); obj != nil {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+func isLDArticle(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return articleLDTypes[v]
+	case []any:
+		for _, t := range v {
+			if s, ok := t.(string); ok && articleLDTypes[s] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func nextDataMetadata(doc *goquery.Document) Metadata {
+	script := doc.Find(nextDataSelector).First()
+	if script.Length() == 0 {
+		return Metadata{}
+	}
+	var data any
+	if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+		return Metadata{}
+	}
+	if obj := findNextDataArticle(data); obj != nil {
+		return metadataFromJSON(obj)
+	}
+	return Metadata{}
+}
+
+// findNextDataArticle returns the first object carrying both a headline
+// and a publication date, which is where the payload keeps the article.
+func findNextDataArticle(value any) map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		if _, ok := v["headline"]; ok {
+			if _, ok := v["datePublished"]; ok {
+				return v
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			if obj := findNextDataArticle(v[key]); obj != nil {
+				return obj
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if obj := findNextDataArticle(child); obj != nil {
+				return obj
+			}
+		}
+	}
+	return nil
+}
+
+// metadataFromJSON reads schema.org-style fields, accepting the common
+// variations (strings, objects with a name or url, and arrays of either).
+func metadataFromJSON(obj map[string]any) Metadata {
+	meta := Metadata{
+		Title:        jsonText(obj["headline"]),
+		Description:  firstNonEmpty(jsonText(obj["description"]), jsonText(obj["rubric"])),
+		Section:      firstNonEmpty(jsonText(obj["articleSection"]), jsonText(obj["section"])),
+		Byline:       firstNonEmpty(jsonText(obj["byline"]), jsonNames(obj["author"])),
+		Published:    jsonTime(obj["datePublished"]),
+		Modified:     jsonTime(obj["dateModified"]),
+		WordCount:    jsonInt(obj["wordCount"]),
+		ImageURL:     jsonURL(obj["image"]),
+		CanonicalURL: firstNonEmpty(jsonURL(obj["url"]), jsonURL(obj["mainEntityOfPage"])),
+	}
+	return meta
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// jsonText returns a string value, or the name of an object, or the first
+// such value in an array.
+func jsonText(value any) string {
+	switch v := value.(type) {
+	case string:
+		return cleanHeaderText(v)
+	case map[string]any:
+		return jsonText(v["name"])
+	case []any:
+		for _, child := range v {
+			if text := jsonText(child); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// jsonNames joins the names of one or more authors.
+func jsonNames(value any) string {
+	list, ok := value.([]any)
+	if !ok {
+		return jsonText(value)
+	}
+	var names []string
+	for _, child := range list {
+		if name := jsonText(child); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// jsonURL returns a URL given as a string, an object with url, canonical
+// or @id, or an array of either.
+func jsonURL(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		for _, key := range []string{"url", "canonical", "@id", "main"} {
+			if u := jsonURL(v[key]); u != "" {
+				return u
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if u := jsonURL(child); u != "" {
+				return u
+			}
+		}
+	}
+	return ""
+}
+
+func jsonTime(value any) time.Time {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05Z0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func jsonInt(value any) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
+
+// formatDateLine formats a date the way the site prints it, e.g.
+// "Mar 26th 2026".
+func formatDateLine(t time.Time) string {
+	day := t.Day()
+	suffix := "th"
+	if day < 11 || day > 13 {
+		switch day % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return t.Format("Jan ") + strconv.Itoa(day) + suffix + t.Format(" 2006")
+}
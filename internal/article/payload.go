@@ -0,0 +1,74 @@
+package article
+
+import "time"
+
+// PayloadVersion is the schema version of Payload. It goes up when a field
+// is renamed, removed or changes meaning; added fields keep the version.
+const PayloadVersion = 1
+
+// Payload is an article's JSON form, written by `read --json` and sent by
+// the daemon. docs/article-json.md documents the schema.
+type Payload struct {
+	Version       int       `json:"version"`
+	URL           string    `json:"url"`
+	CanonicalURL  string    `json:"canonical_url,omitempty"`
+	Overtitle     string    `json:"overtitle,omitempty"`
+	Title         string    `json:"title"`
+	Subtitle      string    `json:"subtitle,omitempty"`
+	DateLine      string    `json:"date_line,omitempty"`
+	Section       string    `json:"section,omitempty"`
+	Byline        string    `json:"byline,omitempty"`
+	Published     time.Time `json:"published,omitzero"`
+	Modified      time.Time `json:"modified,omitzero"`
+	FetchedAt     time.Time `json:"fetched_at,omitzero"`
+	WordCount     int       `json:"word_count,omitempty"`
+	ImageURL      string    `json:"image_url,omitempty"`
+	Text          string    `json:"text,omitempty"`
+	Blocks        []Block   `json:"blocks,omitempty"`
+	DebugHTMLPath string    `json:"debug_html_path,omitempty"`
+}
+
+// NewPayload returns the article's JSON form at the current version.
+func NewPayload(a *Article) *Payload {
+	return &Payload{
+		Version:       PayloadVersion,
+		URL:           a.URL,
+		CanonicalURL:  a.CanonicalURL,
+		Overtitle:     a.Overtitle,
+		Title:         a.Title,
+		Subtitle:      a.Subtitle,
+		DateLine:      a.DateLine,
+		Section:       a.Section,
+		Byline:        a.Byline,
+		Published:     a.Published,
+		Modified:      a.Modified,
+		FetchedAt:     a.FetchedAt,
+		WordCount:     a.WordCount,
+		ImageURL:      a.ImageURL,
+		Text:          a.BodyText(),
+		Blocks:        a.Blocks,
+		DebugHTMLPath: a.DebugHTMLPath,
+	}
+}
+
+// Article converts the payload back into an article. Text is derived from
+// the blocks and is not read back.
+func (p *Payload) Article() *Article {
+	return &Article{
+		Overtitle:     p.Overtitle,
+		Title:         p.Title,
+		Subtitle:      p.Subtitle,
+		DateLine:      p.DateLine,
+		Section:       p.Section,
+		Byline:        p.Byline,
+		Published:     p.Published,
+		Modified:      p.Modified,
+		FetchedAt:     p.FetchedAt,
+		WordCount:     p.WordCount,
+		ImageURL:      p.ImageURL,
+		CanonicalURL:  p.CanonicalURL,
+		Blocks:        p.Blocks,
+		URL:           p.URL,
+		DebugHTMLPath: p.DebugHTMLPath,
+	}
+}
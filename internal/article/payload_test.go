@@ -0,0 +1,47 @@
+package article
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPayloadRoundTrip(t *testing.T) {
+	art := &Article{
+		Overtitle: "Finance & economics | Bonds",
+		Title:     "Why safe assets wobble",
+		Subtitle:  "Investors are nervous",
+		DateLine:  "Oct 15th 2026",
+		Section:   "Finance & economics",
+		Byline:    "Our correspondent",
+		Published: time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC),
+		FetchedAt: time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC),
+		WordCount: 4,
+		Blocks:    ParagraphBlocks("Yields rose.\n\nThen fell."),
+		URL:       "https://www.economist.com/finance/2026/10/15/bonds",
+	}
+
+	data, err := json.Marshal(NewPayload(art))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	body := string(data)
+	for _, want := range []string{`"version":1`, `"fetched_at":"2026-10-16T08:30:00Z"`, `"text":"Yields rose.\n\nThen fell."`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %s in %s", want, body)
+		}
+	}
+	if strings.Contains(body, "modified") || strings.Contains(body, "image_url") {
+		t.Fatalf("expected empty fields left out: %s", body)
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := payload.Article(); !reflect.DeepEqual(got, art) {
+		t.Fatalf("round trip changed the article:\n%#v\n%#v", got, art)
+	}
+}
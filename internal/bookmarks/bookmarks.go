@@ -0,0 +1,192 @@
+package bookmarks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/config"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const bookmarksDirName = "bookmarks"
+
+// Bookmark is an article marked for later, with optional tags and a note.
+// Unlike library entries, bookmarks keep only the headline, not the text.
+type Bookmark struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Section     string    `json:"section,omitempty"`
+	PubDate     string    `json:"pub_date,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// HasTag reports whether the bookmark carries the tag, ignoring case.
+func (b Bookmark) HasTag(tag string) bool {
+	tag = normalizeTag(tag)
+	for _, t := range b.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), bookmarksDirName)
+}
+
+func bookmarkPath(url string) string {
+	return filepath.Join(Dir(), store.KeyName(url))
+}
+
+// Add bookmarks the article. Bookmarking it again adds any new tags,
+// replaces the note and details when given, keeps the saved ones
+// otherwise, and keeps the original add time.
+func Add(b Bookmark) error {
+	b.URL = strings.TrimSpace(b.URL)
+	if b.URL == "" {
+		return appErrors.NewUserError("a bookmark needs a URL")
+	}
+	b.AddedAt = time.Now().UTC()
+	if existing, ok, err := Load(b.URL); err == nil && ok {
+		b.AddedAt = existing.AddedAt
+		b.Tags = append(existing.Tags, b.Tags...)
+		if b.Note == "" {
+			b.Note = existing.Note
+		}
+		if b.Title == "" {
+			b.Title = existing.Title
+		}
+		if b.Description == "" {
+			b.Description = existing.Description
+		}
+		if b.Section == "" {
+			b.Section = existing.Section
+		}
+		if b.PubDate == "" {
+			b.PubDate = existing.PubDate
+		}
+	}
+	if b.Title == "" {
+		b.Title = b.URL
+	}
+	b.Tags = normalizeTags(b.Tags)
+	return store.WriteJSON(bookmarkPath(b.URL), b)
+}
+
+// Load returns the bookmark for the URL.
+func Load(url string) (*Bookmark, bool, error) {
+	var b Bookmark
+	ok, err := store.ReadJSON(bookmarkPath(url), &b)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return &b, true, nil
+}
+
+// Contains reports whether the URL is bookmarked.
+func Contains(url string) bool {
+	_, err := os.Stat(bookmarkPath(url))
+	return err == nil
+}
+
+// Remove deletes the bookmark. It reports whether one existed.
+func Remove(url string) (bool, error) {
+	return store.Remove(bookmarkPath(url))
+}
+
+// Toggle removes the bookmark if the article is bookmarked and adds it
+// otherwise. It reports whether the article is bookmarked afterwards.
+func Toggle(b Bookmark) (bool, error) {
+	removed, err := Remove(b.URL)
+	if err != nil || removed {
+		return false, err
+	}
+	return true, Add(b)
+}
+
+// List returns every bookmark, most recently added first. A non-empty tag
+// keeps only the bookmarks carrying it.
+func List(tag string) ([]Bookmark, error) {
+	files, err := os.ReadDir(Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	bookmarks := make([]Bookmark, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		var b Bookmark
+		if ok, err := store.ReadJSON(filepath.Join(Dir(), file.Name()), &b); err != nil || !ok {
+			continue
+		}
+		if tag != "" && !b.HasTag(tag) {
+			continue
+		}
+		bookmarks = append(bookmarks, b)
+	}
+
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		return bookmarks[i].AddedAt.After(bookmarks[j].AddedAt)
+	})
+	return bookmarks, nil
+}
+
+// Resolve finds a bookmark by URL or by its 1-based position in List.
+func Resolve(ref string) (*Bookmark, error) {
+	ref = strings.TrimSpace(ref)
+	if n, err := strconv.Atoi(ref); err == nil {
+		bookmarks, err := List("")
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 || n > len(bookmarks) {
+			return nil, appErrors.NewUserError("no bookmark %d - run 'economist bookmarks list'", n)
+		}
+		return &bookmarks[n-1], nil
+	}
+
+	b, ok, err := Load(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, appErrors.NewUserError("not bookmarked: %s", ref)
+	}
+	return b, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+	}
+	sort.Strings(out)
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package bookmarks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+func setTempHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestAddMergesTagsAndKeepsAddTime(t *testing.T) {
+	setTempHome(t)
+	url := "https://www.economist.com/finance/bonds"
+	first := Bookmark{
+		URL:         url,
+		Title:       "Bonds",
+		Description: "Yields are rising",
+		Section:     "finance",
+		PubDate:     "Mon, 12 Oct 2026 10:00:00 +0000",
+		Tags:        []string{"Markets", "#debt"},
+	}
+	if err := Add(first); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	saved, _, _ := Load(url)
+
+	time.Sleep(10 * time.Millisecond)
+	if err := Add(Bookmark{URL: url, Tags: []string{"markets", "weekly"}, Note: "read before Friday"}); err != nil {
+		t.Fatalf("re-add: %v", err)
+	}
+	b, ok, err := Load(url)
+	if err != nil || !ok {
+		t.Fatalf("load: ok=%v err=%v", ok, err)
+	}
+	if strings.Join(b.Tags, ",") != "debt,markets,weekly" {
+		t.Fatalf("expected merged tags, got %v", b.Tags)
+	}
+	if b.Title != "Bonds" || b.Note != "read before Friday" || !b.AddedAt.Equal(saved.AddedAt) {
+		t.Fatalf("unexpected bookmark: %#v", b)
+	}
+	if b.Description != first.Description || b.Section != first.Section || b.PubDate != first.PubDate {
+		t.Fatalf("expected details kept from the first add, got %#v", b)
+	}
+}
+
+func TestToggleListAndResolve(t *testing.T) {
+	setTempHome(t)
+	item := rss.Item{Title: "China's economy", Link: "https://www.economist.com/china/economy", PubDate: "Mon, 12 Oct 2026 10:00:00 +0000"}
+
+	added, err := Toggle(FromItem(item, "china"))
+	if err != nil || !added {
+		t.Fatalf("toggle on: added=%v err=%v", added, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := Add(Bookmark{URL: "https://www.economist.com/leaders/one", Title: "One", Tags: []string{"weekly"}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	all, err := List("")
+	if err != nil || len(all) != 2 || all[0].Title != "One" {
+		t.Fatalf("expected newest first, got %#v (err %v)", all, err)
+	}
+	tagged, _ := List("Weekly")
+	if len(tagged) != 1 || tagged[0].Title != "One" {
+		t.Fatalf("expected tag filter, got %#v", tagged)
+	}
+	if b, err := Resolve("2"); err != nil || b.Section != "china" {
+		t.Fatalf("resolve by number: %#v %v", b, err)
+	}
+	if _, err := Resolve("3"); !appErrors.IsUserError(err) {
+		t.Fatalf("expected user error for out-of-range number, got %v", err)
+	}
+
+	added, err = Toggle(FromItem(item, "china"))
+	if err != nil || added || Contains(item.Link) {
+		t.Fatalf("toggle off: added=%v err=%v", added, err)
+	}
+}
+
+func TestSectionItemsAndMarkdown(t *testing.T) {
+	setTempHome(t)
+	if err := Add(Bookmark{URL: "https://www.economist.com/a", Title: "A [draft]", Description: "Why it matters", Tags: []string{"team"}, Note: "discuss"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	title, items, err := Section()
+	if err != nil || title != "Saved" || len(items) != 1 {
+		t.Fatalf("section: %q %#v %v", title, items, err)
+	}
+	if items[0].Description != "#team · discuss" {
+		t.Fatalf("expected tags and note as description, got %q", items[0].Description)
+	}
+
+	all, _ := List("")
+	md := ToMarkdown("Reading list", all)
+	for _, want := range []string{"# Reading list", `- [A \[draft\]](https://www.economist.com/a) — Why it matters`, "  Tags: team", "  Note: discuss"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("expected %q in markdown:\n%s", want, md)
+		}
+	}
+}
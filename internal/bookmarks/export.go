@@ -0,0 +1,33 @@
+package bookmarks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToMarkdown renders bookmarks as a Markdown reading list, ready to paste
+// into chat or a document.
+func ToMarkdown(title string, bookmarks []Bookmark) string {
+	var b strings.Builder
+	if title != "" {
+		b.WriteString("# " + title + "\n\n")
+	}
+	for _, bm := range bookmarks {
+		b.WriteString(fmt.Sprintf("- [%s](%s)", escapeLinkText(bm.Title), bm.URL))
+		if bm.Description != "" {
+			b.WriteString(" — " + bm.Description)
+		}
+		b.WriteString("\n")
+		if len(bm.Tags) > 0 {
+			b.WriteString("  Tags: " + strings.Join(bm.Tags, ", ") + "\n")
+		}
+		if bm.Note != "" {
+			b.WriteString("  Note: " + bm.Note + "\n")
+		}
+	}
+	return b.String()
+}
+
+func escapeLinkText(text string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(text)
+}
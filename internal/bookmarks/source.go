@@ -0,0 +1,72 @@
+package bookmarks
+
+import (
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+// SectionName is the browse pseudo-section listing bookmarks.
+const SectionName = "saved"
+
+const sectionTitle = "Saved"
+
+// SectionInfo describes the Saved pseudo-section, which cycles with the
+// feed sections in browse.
+func SectionInfo() rss.SectionInfo {
+	return rss.SectionInfo{Primary: SectionName, Path: SectionName, Aliases: []string{SectionName, "bookmarks"}}
+}
+
+// Section returns the Saved pseudo-section's title and items.
+func Section() (string, []rss.Item, error) {
+	bookmarks, err := List("")
+	if err != nil {
+		return "", nil, err
+	}
+	return sectionTitle, Items(bookmarks), nil
+}
+
+// Items converts bookmarks to feed items. Tags and the note stand in for
+// the description when there are any.
+func Items(bookmarks []Bookmark) []rss.Item {
+	items := make([]rss.Item, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		items = append(items, rss.Item{
+			Title:       b.Title,
+			Description: itemDescription(b),
+			Link:        b.URL,
+			GUID:        b.URL,
+			PubDate:     b.PubDate,
+			Section:     b.Section,
+		})
+	}
+	return items
+}
+
+func itemDescription(b Bookmark) string {
+	var parts []string
+	if len(b.Tags) > 0 {
+		parts = append(parts, "#"+strings.Join(b.Tags, " #"))
+	}
+	if b.Note != "" {
+		parts = append(parts, b.Note)
+	}
+	if len(parts) == 0 {
+		return b.Description
+	}
+	return strings.Join(parts, " · ")
+}
+
+// FromItem returns a bookmark for a listed feed item.
+func FromItem(item rss.Item, section string) Bookmark {
+	if item.Section != "" {
+		section = item.Section
+	}
+	return Bookmark{
+		URL:         strings.TrimSpace(item.Link),
+		Title:       item.CleanTitle(),
+		Description: item.CleanDescription(),
+		Section:     section,
+		PubDate:     item.PubDate,
+	}
+}
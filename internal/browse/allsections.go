@@ -0,0 +1,73 @@
+package browse
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+type allSectionsMsg struct {
+	items []rss.Item
+	err   error
+}
+
+// toggleAllSections switches the search bar between the current section
+// and the merged items of every section. The feeds are reloaded on each
+// switch; the RSS cache makes that cheap when they were prefetched.
+func (m Model) toggleAllSections() (tea.Model, tea.Cmd) {
+	m.cursor = 0
+	m.browseStart = 0
+	if m.allSections {
+		m.allSections = false
+		m.allSectionsLoading = false
+		m.applySearch()
+		return m, nil
+	}
+
+	if m.fullText {
+		m.fullText = false
+		m.searchIndex = nil
+		m.searchQuery = ""
+	}
+	m.allSections = true
+	m.allSectionsLoading = true
+	m.sectionErr = nil
+	m.applySearch()
+	return m, m.fetchAllSectionsCmd()
+}
+
+func (m Model) fetchAllSectionsCmd() tea.Cmd {
+	source := m.source
+	if source == nil {
+		source = rssSource{debug: m.opts.Debug}
+	}
+	sections := m.sections
+	return func() tea.Msg {
+		items, err := rss.MergeSections(sections, func(section string) ([]rss.Item, error) {
+			_, items, err := fetchSection(source, section)
+			return items, err
+		})
+		return allSectionsMsg{items: items, err: err}
+	}
+}
+
+func (m Model) updateAllSections(msg allSectionsMsg) (tea.Model, tea.Cmd) {
+	if !m.allSections {
+		return m, nil
+	}
+	m.allSectionsLoading = false
+	if msg.err != nil {
+		m.sectionErr = msg.err
+		return m, nil
+	}
+	m.crossItems = msg.items
+	m.applySearch()
+	return m, nil
+}
+
+// searchItems returns the items the search bar filters.
+func (m Model) searchItems() []rss.Item {
+	if m.allSections {
+		return m.crossItems
+	}
+	return m.allItems
+}
@@ -0,0 +1,238 @@
+package browse
+
+import (
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/annotations"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+// loadAnnotations returns the saved annotations on the article, anchored to
+// its current paragraphs.
+func loadAnnotations(art *article.Article, debug bool) *annotations.Document {
+	doc, err := annotations.Load(art)
+	if err != nil {
+		logging.Debugf(debug, "browse: load annotations: %v", err)
+	}
+	doc.Anchor(art)
+	return doc
+}
+
+// paragraphBlocks returns the indexes of the article's body paragraphs, the
+// blocks the paragraph cursor can select.
+func (m Model) paragraphBlocks() []int {
+	if m.article == nil {
+		return nil
+	}
+	blocks := make([]int, 0, len(m.article.Blocks))
+	for i, block := range m.article.Blocks {
+		if block.Kind == article.BlockParagraph && block.PlainText() != "" {
+			blocks = append(blocks, i)
+		}
+	}
+	return blocks
+}
+
+// paragraphMarks returns the margin marks for the annotated paragraphs and
+// the paragraph cursor.
+func (m Model) paragraphMarks() map[int]ui.ParagraphMark {
+	marks := make(map[int]ui.ParagraphMark)
+	if m.annotations != nil {
+		for _, a := range m.annotations.Annotations {
+			if a.Block < 0 {
+				continue
+			}
+			marks[a.Block] = ui.ParagraphMark{Highlight: a.Highlight, Note: a.Note != ""}
+		}
+	}
+	if m.paraMode {
+		mark := marks[m.paraBlock]
+		mark.Cursor = true
+		marks[m.paraBlock] = mark
+	}
+	if len(marks) == 0 {
+		return nil
+	}
+	return marks
+}
+
+// enterParagraphMode puts the paragraph cursor on the paragraph at about
+// the same point in the article as the scroll position.
+func (m Model) enterParagraphMode() (tea.Model, tea.Cmd) {
+	if m.opts.Ephemeral || m.loading || m.article == nil || m.annotations == nil {
+		return m, nil
+	}
+	blocks := m.paragraphBlocks()
+	if len(blocks) == 0 {
+		m.articleStatus = "no paragraphs to annotate"
+		return m, nil
+	}
+	fraction, _ := m.articleProgress()
+	m.paraMode = true
+	m.paraBlock = blocks[int(fraction*float64(len(blocks)-1)+0.5)]
+	m.redrawArticle()
+	return m, nil
+}
+
+func (m Model) updateParagraph(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.noteEditing {
+		return m.updateNoteInput(msg)
+	}
+	m.articleStatus = ""
+
+	switch msg.String() {
+	case "ctrl+c", "ctrl+d", "q":
+		return m, tea.Sequence(m.saveProgressCmd(), tea.Quit)
+	case "esc", "p":
+		m.paraMode = false
+		m.redrawArticle()
+	case "up", "k":
+		m.moveParagraph(-1)
+	case "down", "j":
+		m.moveParagraph(1)
+	case "h":
+		a := m.currentAnnotation()
+		a.Highlight = !a.Highlight
+		return m.setAnnotation(a)
+	case "n":
+		m.noteEditing = true
+		m.noteInput = m.currentAnnotation().Note
+	case "x":
+		return m.setAnnotation(annotations.Annotation{Block: m.paraBlock})
+	}
+	return m, nil
+}
+
+func (m Model) updateNoteInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC, tea.KeyCtrlD:
+		return m, tea.Sequence(m.saveProgressCmd(), tea.Quit)
+	case tea.KeyEsc:
+		m.noteEditing = false
+		m.noteInput = ""
+	case tea.KeyEnter:
+		a := m.currentAnnotation()
+		a.Note = m.noteInput
+		m.noteEditing = false
+		m.noteInput = ""
+		return m.setAnnotation(a)
+	case tea.KeyBackspace:
+		runes := []rune(m.noteInput)
+		if len(runes) > 0 {
+			m.noteInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.noteInput += " "
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if unicode.IsPrint(r) {
+				m.noteInput += string(r)
+			}
+		}
+	}
+	return m, nil
+}
+
+// moveParagraph moves the paragraph cursor and scrolls it into view.
+func (m *Model) moveParagraph(delta int) {
+	blocks := m.paragraphBlocks()
+	for i, block := range blocks {
+		if block != m.paraBlock {
+			continue
+		}
+		next := ui.Clamp(i+delta, 0, len(blocks)-1)
+		m.paraBlock = blocks[next]
+		break
+	}
+	m.redrawArticle()
+}
+
+// currentAnnotation returns the annotation on the selected paragraph, or a
+// new one quoting it.
+func (m Model) currentAnnotation() annotations.Annotation {
+	if a, ok := m.annotations.Get(m.paraBlock); ok {
+		return a
+	}
+	return annotations.Annotation{Block: m.paraBlock}
+}
+
+// setAnnotation stores the annotation on the selected paragraph, redraws
+// the article and saves the article's annotations.
+func (m Model) setAnnotation(a annotations.Annotation) (tea.Model, tea.Cmd) {
+	a.Block = m.paraBlock
+	a.Quote = m.article.Blocks[m.paraBlock].PlainText()
+	doc := *m.annotations
+	doc.Annotations = append([]annotations.Annotation(nil), m.annotations.Annotations...)
+	doc.Set(a)
+	m.annotations = &doc
+	m.redrawArticle()
+	return m, saveAnnotationsCmd(doc)
+}
+
+type annotationsSavedMsg struct {
+	url string
+	err error
+}
+
+func saveAnnotationsCmd(doc annotations.Document) tea.Cmd {
+	annotations.Queue(doc)
+	return func() tea.Msg {
+		return annotationsSavedMsg{url: doc.URL, err: annotations.SaveQueued(doc.URL)}
+	}
+}
+
+func (m Model) updateAnnotationsSaved(msg annotationsSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil && m.article != nil && msg.url == m.article.URL {
+		m.articleStatus = "annotation not saved: " + msg.err.Error()
+	}
+	return m, nil
+}
+
+// redrawArticle re-renders the article after its marks change, keeping the
+// paragraph cursor in view.
+func (m *Model) redrawArticle() {
+	m.articleBase = ""
+	m.refreshArticleLines()
+	if m.paraMode {
+		m.ensureParagraphVisible()
+	}
+}
+
+// ensureParagraphVisible scrolls so the first line of the selected
+// paragraph is on screen.
+func (m *Model) ensureParagraphVisible() {
+	for i, line := range m.articleLines {
+		if !strings.Contains(ui.StripANSI(line), strings.TrimSpace(ui.ParagraphCursorMarker)) {
+			continue
+		}
+		viewHeight := m.articleViewHeight()
+		if i < m.scroll || i >= m.scroll+viewHeight {
+			m.scroll = i - viewHeight/3
+		}
+		m.clampArticleScroll()
+		return
+	}
+}
+
+// paragraphHint returns the note on the selected paragraph, or the note
+// being typed.
+func (m Model) paragraphHint() string {
+	if m.noteEditing {
+		return "note: " + m.noteInput + "│"
+	}
+	if a, ok := m.annotations.Get(m.paraBlock); ok && a.Note != "" {
+		return "✎ " + a.Note
+	}
+	return ""
+}
+
+func (m Model) paragraphHelp() string {
+	if m.noteEditing {
+		return noteInputHelp
+	}
+	return paragraphHelp
+}
@@ -0,0 +1,93 @@
+package browse
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+type bookmarkMsg struct {
+	url   string
+	added bool
+	err   error
+}
+
+// toggleBookmarkCmd bookmarks the item, or removes its bookmark.
+func toggleBookmarkCmd(b bookmarks.Bookmark) tea.Cmd {
+	return func() tea.Msg {
+		added, err := bookmarks.Toggle(b)
+		return bookmarkMsg{url: b.URL, added: added, err: err}
+	}
+}
+
+// toggleListBookmark toggles the bookmark on the selected headline.
+func (m Model) toggleListBookmark() (tea.Model, tea.Cmd) {
+	if m.opts.Ephemeral || m.cursor < 0 || m.cursor >= len(m.filteredItems) {
+		return m, nil
+	}
+	item := m.filteredItems[m.cursor]
+	return m, toggleBookmarkCmd(bookmarks.FromItem(item, m.currentSection()))
+}
+
+// toggleArticleBookmark toggles the bookmark on the article being read,
+// preferring the headline it was opened from.
+func (m Model) toggleArticleBookmark() (tea.Model, tea.Cmd) {
+	if m.opts.Ephemeral || m.loading || m.article == nil {
+		return m, nil
+	}
+	var b bookmarks.Bookmark
+	if m.loadingItem != nil {
+		b = bookmarks.FromItem(*m.loadingItem, m.currentSection())
+	}
+	if b.URL == "" {
+		b.URL = m.article.URL
+	}
+	if m.article.Title != "" {
+		b.Title = m.article.Title
+	}
+	if b.Description == "" {
+		b.Description = m.article.Subtitle
+	}
+	if b.Section == "" {
+		b.Section = m.article.Section
+	}
+	return m, toggleBookmarkCmd(b)
+}
+
+func (m Model) updateBookmark(msg bookmarkMsg) (tea.Model, tea.Cmd) {
+	status := bookmarkStatus(msg)
+	if m.mode == modeArticle {
+		m.articleStatus = status
+	} else {
+		m.listStatus = status
+	}
+
+	// A bookmark removed while listing Saved leaves the list.
+	if msg.err == nil && !msg.added && m.currentSection() == bookmarks.SectionName {
+		m.allItems = withoutLink(m.allItems, msg.url)
+		m.rememberSection(bookmarks.SectionName, m.allItems)
+		m.applySearch()
+	}
+	return m, nil
+}
+
+func bookmarkStatus(msg bookmarkMsg) string {
+	switch {
+	case msg.err != nil:
+		return "bookmark failed: " + msg.err.Error()
+	case msg.added:
+		return bookmarkAddedStatus
+	default:
+		return bookmarkRemovedStatus
+	}
+}
+
+func withoutLink(items []rss.Item, link string) []rss.Item {
+	kept := make([]rss.Item, 0, len(items))
+	for _, item := range items {
+		if item.Link != link {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
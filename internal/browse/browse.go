@@ -5,14 +5,22 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tmustier/economist-tui/internal/app"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
 type Options struct {
-	Debug   bool
-	NoColor bool
-	Source  DataSource
+	Debug     bool
+	NoColor   bool
+	Source    DataSource
+	FrontPage bool // start on the front page instead of the section list
+
+	// ReadState records opened headlines; nil disables read tracking.
+	ReadState *readstate.State
+	// Ephemeral records nothing: no read state and no reading history.
+	Ephemeral bool
 }
 
 func Run(section string, opts Options) error {
@@ -30,11 +38,27 @@ func Run(section string, opts Options) error {
 		go rss.PrefetchAll()
 	}
 
+	if opts.ReadState == nil && !opts.Ephemeral {
+		state, err := readstate.Load()
+		if err != nil {
+			logging.Debugf(opts.Debug, "browse: read state: %v", err)
+		}
+		opts.ReadState = state
+	}
+
+	initial := app.ScreenBrowse
+	if opts.FrontPage {
+		initial = app.ScreenAll
+	}
+
 	ui.InitTheme()
-	host, err := app.NewHost(app.ScreenBrowse, map[app.ScreenID]app.ScreenBuilder{
+	host, err := app.NewHost(initial, map[app.ScreenID]app.ScreenBuilder{
 		app.ScreenBrowse: func() tea.Model {
 			return NewModel(section, items, sectionTitle, opts, source)
 		},
+		app.ScreenAll: func() tea.Model {
+			return NewFrontPage(opts, source)
+		},
 	})
 	if err != nil {
 		return err
@@ -45,7 +69,7 @@ func Run(section string, opts Options) error {
 }
 
 func loadSection(source DataSource, section string) (string, []rss.Item, error) {
-	sectionTitle, items, err := source.Section(section)
+	sectionTitle, items, err := fetchSection(source, section)
 	if err != nil {
 		return "", nil, err
 	}
@@ -0,0 +1,352 @@
+package browse
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/app"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+type frontPageMsg struct {
+	index int
+	title string
+	items []rss.Item
+	err   error
+}
+
+type frontPageBlock struct {
+	info    rss.SectionInfo
+	title   string
+	items   []rss.Item
+	loading bool
+	err     error
+}
+
+// FrontPage is the newspaper-style overview: the top headlines of every
+// section stacked on one screen. Articles open in an embedded reader.
+type FrontPage struct {
+	blocks  []frontPageBlock
+	section int
+	item    int
+	scroll  int
+	width   int
+	height  int
+
+	reader *Model
+
+	source DataSource
+	opts   Options
+}
+
+func NewFrontPage(opts Options, source DataSource) FrontPage {
+	if source == nil {
+		source = rssSource{debug: opts.Debug}
+	}
+	sections := sourceSections(source, opts)
+	blocks := make([]frontPageBlock, len(sections))
+	for i, info := range sections {
+		blocks[i] = frontPageBlock{info: info, title: info.Primary, loading: true}
+	}
+	w, h := ui.TermSize(int(os.Stdout.Fd()))
+	return FrontPage{blocks: blocks, width: w, height: h, source: source, opts: opts}
+}
+
+func (m FrontPage) Init() tea.Cmd {
+	cmds := []tea.Cmd{tea.WindowSize()}
+	for i, block := range m.blocks {
+		if block.loading {
+			cmds = append(cmds, m.fetchBlockCmd(i))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m FrontPage) fetchBlockCmd(index int) tea.Cmd {
+	source := m.source
+	section := m.blocks[index].info.Primary
+	return func() tea.Msg {
+		title, items, err := loadSection(source, section)
+		if len(items) > frontPageItems {
+			items = items[:frontPageItems]
+		}
+		return frontPageMsg{index: index, title: title, items: items, err: err}
+	}
+}
+
+func (m FrontPage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case frontPageMsg:
+		if msg.index < 0 || msg.index >= len(m.blocks) {
+			return m, nil
+		}
+		block := &m.blocks[msg.index]
+		block.loading = false
+		block.err = msg.err
+		if msg.err == nil {
+			block.title = msg.title
+			block.items = msg.items
+		}
+		m.clampCursor()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.ensureCursorVisible()
+	case tea.KeyMsg:
+		if m.reader == nil {
+			return m.updateKeys(msg)
+		}
+	}
+
+	if m.reader != nil {
+		return m.updateReader(msg)
+	}
+	return m, nil
+}
+
+// updateReader forwards the message to the open reader, and closes it
+// once the reader goes back to its list.
+func (m FrontPage) updateReader(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.reader.Update(msg)
+	reader := updated.(Model)
+	if reader.mode == modeBrowse {
+		m.reader = nil
+		return m, cmd
+	}
+	m.reader = &reader
+	return m, cmd
+}
+
+func (m FrontPage) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+d", "q", "esc":
+		return m, tea.Quit
+	case "ctrl+o":
+		return m, switchScreenCmd(app.ScreenBrowse)
+	case "up", "k":
+		m.moveItem(-1)
+	case "down", "j":
+		m.moveItem(1)
+	case "tab", "right":
+		m.moveSection(1)
+	case "shift+tab", "left":
+		m.moveSection(-1)
+	case "home":
+		m.section, m.item = 0, 0
+		m.clampCursor()
+	case "end":
+		m.section, m.item = len(m.blocks)-1, 0
+		m.clampCursor()
+	case "enter":
+		return m.openSelected()
+	}
+	m.ensureCursorVisible()
+	return m, nil
+}
+
+func (m FrontPage) openSelected() (tea.Model, tea.Cmd) {
+	if m.section >= len(m.blocks) || m.item >= len(m.blocks[m.section].items) {
+		return m, nil
+	}
+	block := m.blocks[m.section]
+	reader := NewModel(block.info.Primary, block.items, block.title, m.opts, m.source)
+	reader.width = m.width
+	reader.height = m.height
+	reader, cmd := reader.openItem(m.item)
+	m.reader = &reader
+	return m, cmd
+}
+
+// moveItem moves the cursor by one headline, crossing into the next or
+// previous section with headlines.
+func (m *FrontPage) moveItem(delta int) {
+	next := m.item + delta
+	if m.section < len(m.blocks) && next >= 0 && next < len(m.blocks[m.section].items) {
+		m.item = next
+		return
+	}
+	for i := m.section + delta; i >= 0 && i < len(m.blocks); i += delta {
+		if count := len(m.blocks[i].items); count > 0 {
+			m.section = i
+			m.item = 0
+			if delta < 0 {
+				m.item = count - 1
+			}
+			return
+		}
+	}
+}
+
+// moveSection jumps to the first headline of the next or previous section
+// with headlines, wrapping around.
+func (m *FrontPage) moveSection(delta int) {
+	count := len(m.blocks)
+	for step := 1; step < count; step++ {
+		i := ((m.section+delta*step)%count + count) % count
+		if len(m.blocks[i].items) > 0 {
+			m.section = i
+			m.item = 0
+			return
+		}
+	}
+}
+
+func (m *FrontPage) clampCursor() {
+	if len(m.blocks) == 0 {
+		m.section, m.item = 0, 0
+		return
+	}
+	m.section = ui.Clamp(m.section, 0, len(m.blocks)-1)
+	if len(m.blocks[m.section].items) == 0 {
+		m.moveItem(1)
+	}
+	m.item = ui.Clamp(m.item, 0, ui.Max(0, len(m.blocks[m.section].items)-1))
+}
+
+func (m *FrontPage) ensureCursorVisible() {
+	_, cursorLine := m.contentLines(ui.NewBrowseStyles(m.opts.NoColor))
+	visible := m.visibleLines()
+	if cursorLine < m.scroll+frontPageSectionLines {
+		m.scroll = cursorLine - frontPageSectionLines
+	} else if cursorLine >= m.scroll+visible {
+		m.scroll = cursorLine - visible + 1
+	}
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
+
+func (m FrontPage) visibleLines() int {
+	spec := ui.LayoutSpec{
+		HeaderLines:     frontPageHeaderLines,
+		FooterLines:     frontPageFooterLines,
+		FooterPadding:   browseFooterPadding,
+		MinVisibleLines: browseMinVisibleLines,
+	}
+	return spec.VisibleLines(m.height)
+}
+
+func (m FrontPage) contentWidth() int {
+	termWidth := m.width
+	if termWidth <= 0 {
+		termWidth = ui.DefaultWidth
+	}
+	return ui.ReaderContentWidth(termWidth)
+}
+
+// contentLines renders every section block and returns the lines with the
+// index of the line holding the cursor.
+func (m FrontPage) contentLines(styles ui.BrowseStyles) ([]string, int) {
+	contentWidth := m.contentWidth()
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), m.opts.NoColor)
+	dateLayout := ui.ResolveDateLayout(contentWidth, frontPagePrefixWidth)
+	listStyles := ui.ListStyles{
+		Title:         styles.Title,
+		Subtitle:      styles.Subtitle,
+		Selected:      styles.Selected,
+		Right:         styles.Dim,
+		RightSelected: styles.Selected,
+		Read:          styles.Read,
+	}
+
+	var lines []string
+	cursorLine := 0
+	for i, block := range m.blocks {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, styles.Header.Render(block.title), ui.SectionRule(contentWidth, accentStyles))
+
+		switch {
+		case block.loading:
+			lines = append(lines, styles.Dim.Render("  loading…"))
+			continue
+		case block.err != nil:
+			lines = append(lines, styles.Dim.Render(fmt.Sprintf("  error: %v", block.err)))
+			continue
+		case len(block.items) == 0:
+			lines = append(lines, styles.Dim.Render("  No articles"))
+			continue
+		}
+
+		listItems := make([]ui.ListItem, len(block.items))
+		for j, item := range block.items {
+			date := item.FormattedDate()
+			if dateLayout.Compact {
+				date = item.CompactDate()
+			}
+			listItems[j] = ui.ListItem{Title: item.CleanTitle(), Right: date, Read: m.opts.ReadState.IsRead(item.Key())}
+		}
+		selected := -1
+		if i == m.section {
+			selected = m.item
+		}
+		rendered := ui.RenderList(listItems, ui.ListOptions{
+			Width:            contentWidth,
+			PrefixWidth:      frontPagePrefixWidth,
+			RightColumnWidth: dateLayout.ColumnWidth,
+			TitleLines:       1,
+			SelectedIndex:    selected,
+			Start:            0,
+			End:              len(listItems),
+			Prefix: func(index int) string {
+				if index == selected {
+					return styles.Selected.Render("› ")
+				}
+				return "  "
+			},
+		}, listStyles)
+		if i == m.section {
+			cursorLine = len(lines) + m.item
+		}
+		lines = append(lines, strings.Split(strings.TrimRight(rendered, "\n"), "\n")...)
+	}
+	return lines, cursorLine
+}
+
+func (m FrontPage) View() string {
+	if m.reader != nil {
+		return m.reader.View()
+	}
+
+	styles := ui.NewBrowseStyles(m.opts.NoColor)
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), m.opts.NoColor)
+	termWidth := m.width
+	if termWidth <= 0 {
+		termWidth = ui.DefaultWidth
+	}
+	contentWidth := m.contentWidth()
+	indent := ui.ArticleIndent(ui.ArticleRenderOptions{TermWidth: termWidth, WrapWidth: contentWidth, Center: true})
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(styles.Header.Render(frontPageTitle) + "\n")
+	b.WriteString(ui.AccentRule(contentWidth, accentStyles) + "\n\n")
+
+	lines, _ := m.contentLines(styles)
+	start := ui.Min(m.scroll, ui.Max(0, len(lines)-1))
+	end := ui.Min(len(lines), start+m.visibleLines())
+	b.WriteString(strings.Join(lines[start:end], "\n"))
+
+	help := ui.SelectHintLine(contentWidth, frontPageHelpOptions...)
+	footer := ui.BuildFooter(ui.SectionRule(contentWidth, accentStyles), ui.CenterText(styles.Help.Render(help), contentWidth))
+
+	content := b.String()
+	if indent > 0 {
+		content = ui.IndentBlock(content, indent)
+		footer = ui.IndentBlock(footer, indent)
+	}
+	content = ui.PadBlockRight(content, termWidth)
+	footer = ui.PadBlockRight(footer, termWidth)
+	return ui.LayoutWithFooter(content, footer, m.height, browseFooterPadding)
+}
+
+func switchScreenCmd(id app.ScreenID) tea.Cmd {
+	return func() tea.Msg {
+		return app.SwitchScreenMsg{ID: id}
+	}
+}
@@ -0,0 +1,81 @@
+package browse
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/app"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+func testFrontPage() FrontPage {
+	m := FrontPage{
+		blocks: []frontPageBlock{
+			{info: rss.SectionInfo{Primary: "leaders"}, loading: true},
+			{info: rss.SectionInfo{Primary: "china"}, loading: true},
+		},
+		width:  100,
+		height: 40,
+		source: fakeSource{},
+	}
+	next, _ := m.Update(frontPageMsg{index: 0, title: "Leaders", items: []rss.Item{
+		{Title: "First leader", Link: "https://www.economist.com/leaders/one"},
+		{Title: "Second leader", Link: "https://www.economist.com/leaders/two"},
+	}})
+	next, _ = next.Update(frontPageMsg{index: 1, title: "China", items: []rss.Item{
+		{Title: "China story", Link: "https://www.economist.com/china/one"},
+	}})
+	return next.(FrontPage)
+}
+
+func TestFrontPageNavigation(t *testing.T) {
+	m := testFrontPage()
+	view := m.View()
+	for _, want := range []string{"Front page", "Leaders", "Second leader", "China story"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in front page view", want)
+		}
+	}
+
+	for _, key := range []tea.KeyType{tea.KeyDown, tea.KeyDown} {
+		next, _ := m.Update(tea.KeyMsg{Type: key})
+		m = next.(FrontPage)
+	}
+	if m.section != 1 || m.item != 0 {
+		t.Fatalf("expected down to cross into the next section, got %d/%d", m.section, m.item)
+	}
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m = next.(FrontPage)
+	if m.section != 0 || m.item != 0 {
+		t.Fatalf("expected shift+tab to jump to the previous section, got %d/%d", m.section, m.item)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if cmd == nil {
+		t.Fatalf("expected a screen switch command")
+	}
+	if msg, ok := cmd().(app.SwitchScreenMsg); !ok || msg.ID != app.ScreenBrowse {
+		t.Fatalf("expected switch to the browse screen, got %#v", msg)
+	}
+}
+
+func TestFrontPageOpensReader(t *testing.T) {
+	m := testFrontPage()
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	next, cmd := next.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(FrontPage)
+	if m.reader == nil || cmd == nil || m.reader.pendingURL != "https://www.economist.com/leaders/two" {
+		t.Fatalf("expected reader fetching the selected article")
+	}
+
+	next, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = next.(FrontPage)
+	if m.reader != nil {
+		t.Fatalf("expected back to close the reader")
+	}
+	if m.section != 0 || m.item != 1 {
+		t.Fatalf("expected cursor to stay on the opened article")
+	}
+}
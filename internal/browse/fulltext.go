@@ -0,0 +1,77 @@
+package browse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/search"
+)
+
+// toggleFullText switches the search bar between filtering the section's
+// headlines and searching the text of every article read so far. The index
+// is reloaded on each switch so it includes articles opened since.
+func (m Model) toggleFullText() (tea.Model, tea.Cmd) {
+	m.searchQuery = ""
+	m.cursor = 0
+	m.browseStart = 0
+	m.hitWords = nil
+	if m.fullText {
+		m.fullText = false
+		m.searchIndex = nil
+		m.applySearch()
+		return m, nil
+	}
+
+	ix, err := search.OpenIndex()
+	if err != nil {
+		m.sectionErr = fmt.Errorf("search index: %w", err)
+		return m, nil
+	}
+	m.fullText = true
+	m.searchIndex = ix
+	m.allSections = false
+	m.allSectionsLoading = false
+	m.applySearch()
+	return m, nil
+}
+
+func (m *Model) applyFullTextSearch() {
+	query := strings.TrimSpace(m.searchQuery)
+	m.hitWords = nil
+	if query == "" || m.searchIndex == nil {
+		m.filteredItems = nil
+		m.ensureBrowseWindow()
+		return
+	}
+
+	hits := m.searchIndex.Search(query, fullTextLimit)
+	m.filteredItems, m.hitWords = hitItems(hits)
+	if m.cursor >= len(m.filteredItems) {
+		m.cursor = 0
+	}
+	m.ensureBrowseWindow()
+}
+
+// hitItems turns search hits into list items, with the snippet in place of
+// the description, and returns the words to highlight for each URL.
+func hitItems(hits []search.Hit) ([]rss.Item, map[string][]string) {
+	items := make([]rss.Item, 0, len(hits))
+	words := make(map[string][]string, len(hits))
+	for _, hit := range hits {
+		item := rss.Item{
+			Title:       hit.Title,
+			Description: hit.Snippet.Text,
+			Link:        hit.URL,
+			GUID:        hit.URL,
+		}
+		if !hit.Published.IsZero() {
+			item.PubDate = hit.Published.Format(time.RFC1123Z)
+		}
+		items = append(items, item)
+		words[hit.URL] = hit.Snippet.Words()
+	}
+	return items, words
+}
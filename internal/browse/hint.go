@@ -1,6 +1,11 @@
 package browse
 
-import "github.com/tmustier/economist-tui/internal/ui"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/ui"
+)
 
 type helpLineSpec struct {
 	Options []string
@@ -9,6 +14,7 @@ type helpLineSpec struct {
 var browseHelpLineSpecs = []helpLineSpec{
 	{
 		Options: []string{
+			"↑/↓ navigate • ←/→ page • ⇧⇥/⇥ section • ^o front page",
 			"↑/↓ navigate • ←/→ page • ⇧⇥/⇥ section",
 			"↑/↓ move • ←/→ page • ⇧⇥/⇥ section",
 			"↑/↓ • ←/→ • ⇧⇥/⇥",
@@ -18,15 +24,45 @@ var browseHelpLineSpecs = []helpLineSpec{
 	},
 	{
 		Options: []string{
-			"↵ read • esc clear • q quit",
-			"↵ read • esc • q quit",
-			"↵ • esc • q",
+			"↵ read • ^b bookmark • ^a all sections • ^f full text • esc clear • q quit",
+			"↵ read • ^b bookmark • ^a all • ^f full text • esc • q quit",
+			"↵ read • ^a all • ^f full text • esc • q quit",
+			"↵ • ^b • ^a • ^f • esc • q",
 			"↵ • q",
 			"q",
 		},
 	},
 }
 
+// frontPageHelpOptions are the front page help lines, widest first.
+var frontPageHelpOptions = []string{
+	"↑/↓ select • ⇧⇥/⇥ section • ↵ read • ^o section view • q quit",
+	"↑/↓ • ⇧⇥/⇥ section • ↵ read • ^o sections • q quit",
+	"↑/↓ • ⇧⇥/⇥ • ↵ • ^o • q",
+	"↑/↓ • ↵ • q",
+}
+
+// articleHelpOptions are the reader help lines, widest first. %s is the
+// columns on/off label.
+var articleHelpOptions = []string{
+	"b back • ⇧⇥/⇥ prev/next • c columns %s • l links • p annotate • s save • ^b bookmark • ↑/↓ scroll • q quit",
+	"b back • ⇧⇥/⇥ • c columns %s • l links • p annotate • s save • ^b bookmark • ↑/↓ • q quit",
+	"b back • ⇧⇥/⇥ • c columns %s • l links • p annotate • s save • ↑/↓ • q quit",
+	"b • ⇧⇥/⇥ • c %s • l • p • s • ^b • ↑/↓ • q",
+	"b • ⇧⇥/⇥ • q",
+}
+
+func articleHelpLine(width int, columnLabel string) string {
+	options := make([]string, len(articleHelpOptions))
+	for i, option := range articleHelpOptions {
+		if strings.Contains(option, "%s") {
+			option = fmt.Sprintf(option, columnLabel)
+		}
+		options[i] = option
+	}
+	return ui.SelectHintLine(width, options...)
+}
+
 func browseHelpLines(width int) []string {
 	lines := make([]string, 0, len(browseHelpLineSpecs))
 	for _, spec := range browseHelpLineSpecs {
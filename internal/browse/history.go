@@ -0,0 +1,45 @@
+package browse
+
+import (
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/history"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+// articleProgress returns how far down the open article the reader is, as
+// a fraction of the scrollable length, and whether the end is in view.
+func (m Model) articleProgress() (float64, bool) {
+	maxScroll := m.maxArticleScroll()
+	if maxScroll == 0 {
+		return 0, true
+	}
+	scroll := ui.Clamp(m.scroll, 0, maxScroll)
+	return float64(scroll) / float64(maxScroll), scroll >= maxScroll
+}
+
+// restoreScroll scrolls to a fraction of the article's scrollable length,
+// which keeps the reader's place across reflows and terminal sizes.
+func (m *Model) restoreScroll(fraction float64) {
+	m.scroll = int(math.Round(fraction * float64(m.maxArticleScroll())))
+	m.clampArticleScroll()
+}
+
+// saveProgressCmd returns a command recording how far the open article
+// was read, or nil when there is nothing to record.
+func (m Model) saveProgressCmd() tea.Cmd {
+	if m.opts.Ephemeral || m.loading || m.article == nil || m.articleErr != nil || len(m.articleLines) == 0 {
+		return nil
+	}
+	url := m.article.URL
+	fraction, completed := m.articleProgress()
+	debug := m.opts.Debug
+	return func() tea.Msg {
+		if err := history.SaveProgress(url, fraction, completed); err != nil {
+			logging.Debugf(debug, "browse: save reading progress: %v", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package browse
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/annotations"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+// articleFrame is a reader state saved when following a link, restored on back.
+type articleFrame struct {
+	item        *rss.Item
+	article     *article.Article
+	annotations *annotations.Document
+	scroll      int
+}
+
+func (m Model) openLinkPicker() (tea.Model, tea.Cmd) {
+	if m.loading || m.article == nil {
+		return m, nil
+	}
+	links := m.article.Links()
+	if len(links) == 0 {
+		m.articleStatus = "no links in this article"
+		return m, nil
+	}
+	m.links = links
+	m.linkCursor = 0
+	m.linkPicker = true
+	m.articleStatus = ""
+	return m, nil
+}
+
+func (m Model) updateLinkPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+d", "q":
+		return m, tea.Sequence(m.saveProgressCmd(), tea.Quit)
+	case "esc", "b", "l":
+		m.linkPicker = false
+		m.articleStatus = ""
+		return m, nil
+	case "up", "k":
+		if m.linkCursor > 0 {
+			m.linkCursor--
+		}
+	case "down", "j":
+		if m.linkCursor < len(m.links)-1 {
+			m.linkCursor++
+		}
+	case "home":
+		m.linkCursor = 0
+	case "end":
+		m.linkCursor = ui.Max(0, len(m.links)-1)
+	case "enter":
+		return m.followLink()
+	}
+	return m, nil
+}
+
+// followLink opens the selected Economist link in the reader, keeping the
+// current article so back returns to it.
+func (m Model) followLink() (tea.Model, tea.Cmd) {
+	if m.linkCursor < 0 || m.linkCursor >= len(m.links) {
+		return m, nil
+	}
+	link := m.links[m.linkCursor]
+	if !article.IsEconomistURL(link.URL) {
+		m.articleStatus = fmt.Sprintf("external link: %s", link.URL)
+		return m, nil
+	}
+
+	saveProgress := m.saveProgressCmd()
+	m.articleTrail = append(m.articleTrail, articleFrame{
+		item:        m.loadingItem,
+		article:     m.article,
+		annotations: m.annotations,
+		scroll:      m.scroll,
+	})
+	m.linkPicker = false
+	m.articleStatus = ""
+
+	item := rss.Item{Title: link.Text, Link: link.URL}
+	m.loading = true
+	m.loadingItem = &item
+	m.pendingURL = item.Link
+	m.articleErr = nil
+	m.article = nil
+	m.articleBase = ""
+	m.articleLines = nil
+	m.scroll = 0
+	return m, tea.Batch(saveProgress, m.fetchArticleCmd(item.Link))
+}
+
+// popArticleTrail restores the article that was open before following a
+// link. It reports false when there is nothing to go back to.
+func (m *Model) popArticleTrail() bool {
+	if len(m.articleTrail) == 0 {
+		return false
+	}
+	frame := m.articleTrail[len(m.articleTrail)-1]
+	m.articleTrail = m.articleTrail[:len(m.articleTrail)-1]
+
+	m.loading = false
+	m.pendingURL = ""
+	m.loadingItem = frame.item
+	m.article = frame.article
+	m.annotations = frame.annotations
+	m.articleErr = nil
+	m.articleBase = ""
+	m.articleLines = nil
+	m.refreshArticleLines()
+	m.scroll = frame.scroll
+	m.clampArticleScroll()
+	return true
+}
+
+func (m Model) linkPickerView(styles ui.BrowseStyles) string {
+	termWidth := m.width
+	if termWidth <= 0 {
+		termWidth = ui.DefaultWidth
+	}
+	contentWidth := ui.ReaderContentWidth(termWidth)
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), m.opts.NoColor)
+
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(styles.Header.Render(linkPickerTitle) + "\n")
+	b.WriteString(ui.AccentRule(contentWidth, accentStyles) + "\n\n")
+
+	items := make([]ui.ListItem, len(m.links))
+	for i, link := range m.links {
+		title := link.Text
+		if title == "" {
+			title = link.URL
+		}
+		right := ""
+		if !article.IsEconomistURL(link.URL) {
+			right = linkExternalLabel
+		}
+		items[i] = ui.ListItem{Title: title, Subtitle: link.URL, Right: right}
+	}
+
+	maxVisible := (m.articleViewHeight() - linkPickerHeaderLines) / linkPickerItemLines
+	if maxVisible < 1 {
+		maxVisible = 1
+	}
+	start, end := ui.VisibleRange(m.linkCursor, maxVisible, len(items))
+	numWidth := len(fmt.Sprintf("%d", len(items)))
+	prefixWidth := numWidth + 2
+
+	b.WriteString(ui.RenderList(items, ui.ListOptions{
+		Width:            contentWidth,
+		PrefixWidth:      prefixWidth,
+		RightColumnWidth: len(linkExternalLabel) + ui.DefaultDateGap,
+		TitleLines:       1,
+		SubtitleLines:    1,
+		ItemGapLines:     1,
+		SelectedIndex:    m.linkCursor,
+		Start:            start,
+		End:              end,
+		Prefix: func(index int) string {
+			return fmt.Sprintf("%*d. ", numWidth, index+1)
+		},
+	}, ui.ListStyles{
+		Title:         styles.Title,
+		Subtitle:      styles.Dim,
+		Selected:      styles.Selected,
+		Right:         styles.Dim,
+		RightSelected: styles.Selected,
+	}))
+
+	indent := ui.ArticleIndent(ui.ArticleRenderOptions{TermWidth: termWidth, WrapWidth: contentWidth, Center: true})
+	return ui.IndentBlock(b.String(), indent)
+}
@@ -9,7 +9,11 @@ import (
 	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/annotations"
+	"github.com/tmustier/economist-tui/internal/app"
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/history"
+	"github.com/tmustier/economist-tui/internal/library"
 	"github.com/tmustier/economist-tui/internal/logging"
 	"github.com/tmustier/economist-tui/internal/rss"
 	"github.com/tmustier/economist-tui/internal/search"
@@ -28,6 +32,13 @@ type articleMsg struct {
 	article       *article.Article
 	err           error
 	fetchDuration time.Duration
+	resume        float64 // scroll fraction to reopen the article at
+	annotations   *annotations.Document
+}
+
+type savedMsg struct {
+	url string
+	err error
 }
 
 type sectionMsg struct {
@@ -43,6 +54,7 @@ type Model struct {
 	sectionTitle  string
 	sections      []rss.SectionInfo
 	sectionIndex  int
+	sectionItems  map[string][]rss.Item
 
 	pendingSection      string
 	pendingSectionIndex int
@@ -54,6 +66,16 @@ type Model struct {
 	width       int
 	height      int
 	searchQuery string
+	searchErr   error
+	listStatus  string
+
+	fullText    bool
+	searchIndex *search.Index
+	hitWords    map[string][]string
+
+	allSections        bool
+	allSectionsLoading bool
+	crossItems         []rss.Item
 
 	mode         viewMode
 	loading      bool
@@ -66,6 +88,18 @@ type Model struct {
 	scroll       int
 	twoColumn    bool
 
+	linkPicker    bool
+	links         []article.Link
+	linkCursor    int
+	articleStatus string
+	articleTrail  []articleFrame
+
+	annotations *annotations.Document
+	paraMode    bool
+	paraBlock   int
+	noteEditing bool
+	noteInput   string
+
 	fetchDuration  time.Duration
 	baseDuration   time.Duration
 	reflowDuration time.Duration
@@ -79,9 +113,9 @@ func NewModel(section string, items []rss.Item, sectionTitle string, opts Option
 		source = rssSource{debug: opts.Debug}
 	}
 	w, h := ui.TermSize(int(os.Stdout.Fd()))
-	sections := rss.SectionList()
+	sections := sourceSections(source, opts)
 	sectionIndex, sections := resolveSectionIndex(section, sections)
-	return Model{
+	m := Model{
 		allItems:            items,
 		filteredItems:       items,
 		sectionTitle:        sectionTitle,
@@ -94,10 +128,14 @@ func NewModel(section string, items []rss.Item, sectionTitle string, opts Option
 		opts:                opts,
 		pendingSectionIndex: -1,
 	}
+	m.rememberSection(sections[sectionIndex].Primary, items)
+	return m
 }
 
+// Init asks for the window size, which may have changed while another
+// screen was showing.
 func (m Model) Init() tea.Cmd {
-	return nil
+	return tea.WindowSize()
 }
 
 func resolveSectionIndex(section string, sections []rss.SectionInfo) (int, []rss.SectionInfo) {
@@ -127,28 +165,43 @@ func resolveSectionIndex(section string, sections []rss.SectionInfo) (int, []rss
 }
 
 func (m *Model) applySearch() {
+	m.searchErr = nil
+	if m.fullText {
+		m.applyFullTextSearch()
+		return
+	}
+
 	query := strings.TrimSpace(m.searchQuery)
+	items := m.searchItems()
 	if query == "" {
-		m.filteredItems = m.allItems
+		m.filteredItems = items
 		m.ensureBrowseWindow()
 		return
 	}
 
 	if isDigits(query) {
-		m.filteredItems = m.allItems
+		m.filteredItems = items
 		idx, err := strconv.Atoi(query)
-		if err == nil && idx > 0 && idx <= len(m.allItems) {
+		if err == nil && idx > 0 && idx <= len(items) {
 			m.cursor = idx - 1
 		}
 		m.ensureBrowseWindow()
 		return
 	}
 
+	q, err := search.ParseQuery(query)
+	m.searchErr = err
 	var filtered []rss.Item
-	for _, item := range m.allItems {
-		text := item.CleanTitle() + " " + item.CleanDescription()
-		if search.Match(text, query) {
-			filtered = append(filtered, item)
+	if err == nil {
+		current := m.currentSection()
+		for _, item := range items {
+			section := item.Section
+			if section == "" {
+				section = current
+			}
+			if q.Matches(item.SearchFields(section)) {
+				filtered = append(filtered, item)
+			}
 		}
 	}
 	m.filteredItems = filtered
@@ -160,6 +213,14 @@ func (m *Model) applySearch() {
 	m.ensureBrowseWindow()
 }
 
+// currentSection returns the name of the section being listed.
+func (m Model) currentSection() string {
+	if m.sectionIndex >= 0 && m.sectionIndex < len(m.sections) {
+		return m.sections[m.sectionIndex].Primary
+	}
+	return ""
+}
+
 func isDigits(input string) bool {
 	for _, r := range input {
 		if !unicode.IsDigit(r) {
@@ -174,10 +235,26 @@ func (m Model) fetchArticleCmd(url string) tea.Cmd {
 	if source == nil {
 		source = rssSource{debug: m.opts.Debug}
 	}
+	section := m.currentSection()
+	ephemeral := m.opts.Ephemeral
+	debug := m.opts.Debug
 	return func() tea.Msg {
 		start := time.Now()
 		art, err := source.Article(url)
-		return articleMsg{url: url, article: art, err: err, fetchDuration: time.Since(start)}
+		msg := articleMsg{url: url, article: art, err: err, fetchDuration: time.Since(start)}
+		if err != nil || art == nil || ephemeral {
+			return msg
+		}
+		msg.annotations = loadAnnotations(art, debug)
+		if art.Section != "" {
+			section = art.Section
+		}
+		entry, err := history.Opened(url, art.Title, section)
+		if err != nil {
+			logging.Debugf(debug, "browse: record history: %v", err)
+		}
+		msg.resume = entry.Resume()
+		return msg
 	}
 }
 
@@ -211,11 +288,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.sectionIndex = pendingIndex
 		}
 		m.sectionTitle = msg.title
+		m.rememberSection(msg.section, msg.items)
+		m.allSections = false
 		m.allItems = msg.items
 		m.filteredItems = msg.items
 		m.cursor = 0
 		m.browseStart = 0
 		m.applySearch()
+		return m, m.schedulePrefetch(m.cursorURL())
+	case allSectionsMsg:
+		return m.updateAllSections(msg)
+	case bookmarkMsg:
+		return m.updateBookmark(msg)
+	case annotationsSavedMsg:
+		return m.updateAnnotationsSaved(msg)
+	case savedMsg:
+		if m.article != nil && msg.url == m.article.URL {
+			if msg.err != nil {
+				m.articleStatus = fmt.Sprintf("save failed: %v", msg.err)
+			} else {
+				m.articleStatus = articleSavedStatus
+			}
+		}
 		return m, nil
 	case articleMsg:
 		if m.mode != modeArticle || msg.url != m.pendingURL {
@@ -225,6 +319,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.pendingURL = ""
 		m.scroll = 0
 		m.fetchDuration = msg.fetchDuration
+		m.annotations = msg.annotations
+		m.paraMode = false
+		m.noteEditing = false
 		if msg.err != nil {
 			m.articleErr = msg.err
 			m.article = nil
@@ -236,17 +333,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.article = msg.article
 		m.articleBase = ""
 		m.refreshArticleLines()
+		m.restoreScroll(msg.resume)
 		return m, nil
+	case prefetchMsg:
+		return m.updatePrefetch(msg)
 	case tea.KeyMsg:
 		if m.mode == modeArticle {
 			return m.updateArticle(msg)
 		}
-		return m.updateBrowse(msg)
+		before := m.cursorURL()
+		next, cmd := m.updateBrowse(msg)
+		if nm, ok := next.(Model); ok && nm.mode == modeBrowse {
+			if url := nm.cursorURL(); url != before {
+				cmd = tea.Batch(cmd, nm.schedulePrefetch(url))
+			}
+		}
+		return next, cmd
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		if m.mode == modeArticle && m.article != nil {
+			fraction, _ := m.articleProgress()
 			m.refreshArticleLines()
+			m.restoreScroll(fraction)
 		}
 		if m.mode == modeBrowse {
 			m.ensureBrowseWindow()
@@ -256,9 +365,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.listStatus = ""
 	switch msg.String() {
 	case "ctrl+c", "ctrl+d":
 		return m, tea.Quit
+	case "ctrl+b":
+		return m.toggleListBookmark()
+	case "ctrl+f":
+		return m.toggleFullText()
+	case "ctrl+a":
+		return m.toggleAllSections()
+	case "ctrl+o":
+		return m, switchScreenCmd(app.ScreenAll)
 	case "q":
 		if m.searchQuery == "" {
 			return m, tea.Quit
@@ -270,6 +388,10 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.searchQuery != "" {
 			m.searchQuery = ""
 			m.applySearch()
+		} else if m.fullText {
+			return m.toggleFullText()
+		} else if m.allSections {
+			return m.toggleAllSections()
 		} else {
 			return m, tea.Quit
 		}
@@ -284,17 +406,7 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case tea.KeyEnter:
 		if len(m.filteredItems) > 0 && m.cursor < len(m.filteredItems) {
-			item := m.filteredItems[m.cursor]
-			m.mode = modeArticle
-			m.loading = true
-			m.loadingItem = &item
-			m.pendingURL = item.Link
-			m.articleErr = nil
-			m.article = nil
-			m.articleBase = ""
-			m.articleLines = nil
-			m.scroll = 0
-			return m, m.fetchArticleCmd(item.Link)
+			return m.openItem(m.cursor)
 		}
 	case tea.KeyUp:
 		if m.cursor > 0 {
@@ -358,6 +470,25 @@ func (m Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openItem opens the listed item at index in the reader.
+func (m Model) openItem(index int) (Model, tea.Cmd) {
+	item := m.filteredItems[index]
+	m.cursor = index
+	m.mode = modeArticle
+	m.loading = true
+	m.loadingItem = &item
+	m.pendingURL = item.Link
+	m.articleErr = nil
+	m.article = nil
+	m.articleBase = ""
+	m.articleLines = nil
+	m.scroll = 0
+	m.articleTrail = nil
+	m.linkPicker = false
+	m.articleStatus = ""
+	return m, tea.Batch(m.fetchArticleCmd(item.Link), m.markRead(item))
+}
+
 func (m Model) pageBrowse(delta int) (tea.Model, tea.Cmd) {
 	itemCount := len(m.filteredItems)
 	if itemCount == 0 {
@@ -395,27 +526,36 @@ func (m Model) pageBrowse(delta int) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) updateArticle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.linkPicker {
+		return m.updateLinkPicker(msg)
+	}
+	if m.paraMode {
+		return m.updateParagraph(msg)
+	}
+	m.articleStatus = ""
+
 	switch msg.String() {
 	case "ctrl+c", "ctrl+d", "q":
-		return m, tea.Quit
+		return m, tea.Sequence(m.saveProgressCmd(), tea.Quit)
 	case "b", "enter":
-		m.mode = modeBrowse
-		m.loading = false
-		m.loadingItem = nil
-		m.pendingURL = ""
-		return m, nil
+		return m.leaveArticle()
 	case "c":
 		m.twoColumn = !m.twoColumn
 		m.refreshArticleLines()
 		return m, nil
+	case "l":
+		return m.openLinkPicker()
+	case "p":
+		return m.enterParagraphMode()
+	case "s":
+		return m.saveArticle()
+	case "ctrl+b":
+		return m.toggleArticleBookmark()
 	}
 
 	switch msg.Type {
 	case tea.KeyEsc:
-		m.mode = modeBrowse
-		m.loading = false
-		m.loadingItem = nil
-		m.pendingURL = ""
+		return m.leaveArticle()
 	case tea.KeyTab:
 		return m.navigateArticle(1)
 	case tea.KeyShiftTab:
@@ -438,6 +578,31 @@ func (m Model) updateArticle(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// saveArticle stores the open article in the offline library.
+func (m Model) saveArticle() (tea.Model, tea.Cmd) {
+	if m.loading || m.article == nil {
+		return m, nil
+	}
+	art := *m.article
+	return m, func() tea.Msg {
+		return savedMsg{url: art.URL, err: library.Save(&art)}
+	}
+}
+
+// leaveArticle goes back to the article a link was followed from, or to the
+// list when there is none.
+func (m Model) leaveArticle() (tea.Model, tea.Cmd) {
+	saveProgress := m.saveProgressCmd()
+	if m.popArticleTrail() {
+		return m, saveProgress
+	}
+	m.mode = modeBrowse
+	m.loading = false
+	m.loadingItem = nil
+	m.pendingURL = ""
+	return m, saveProgress
+}
+
 func (m Model) queueSectionChange(delta int) (tea.Model, tea.Cmd) {
 	if len(m.sections) == 0 {
 		return m, nil
@@ -481,6 +646,8 @@ func (m Model) navigateArticle(delta int) (tea.Model, tea.Cmd) {
 		newCursor = 0
 	}
 
+	saveProgress := m.saveProgressCmd()
+
 	// Update cursor and browse window
 	m.cursor = newCursor
 	m.ensureBrowseWindow()
@@ -495,8 +662,9 @@ func (m Model) navigateArticle(delta int) (tea.Model, tea.Cmd) {
 	m.articleBase = ""
 	m.articleLines = nil
 	m.scroll = 0
+	m.articleTrail = nil
 
-	return m, m.fetchArticleCmd(item.Link)
+	return m, tea.Batch(saveProgress, m.fetchArticleCmd(item.Link), m.markRead(item))
 }
 
 func (m *Model) refreshArticleLines() {
@@ -508,7 +676,7 @@ func (m *Model) refreshArticleLines() {
 
 	if m.articleBase == "" {
 		baseStart := time.Now()
-		base, err := ui.RenderArticleBodyBase(ui.ArticleBodyMarkdown(m.article), opts)
+		base, err := ui.RenderArticleBodyBase(ui.ArticleBodySource(m.article, opts), opts)
 		m.baseDuration = time.Since(baseStart)
 		if err != nil {
 			m.articleErr = err
@@ -561,6 +729,7 @@ func (m Model) articleRenderOptions() ui.ArticleRenderOptions {
 		TermWidth: termWidth,
 		Center:    center,
 		TwoColumn: m.twoColumn,
+		Marks:     m.paragraphMarks(),
 	}
 }
 
@@ -660,8 +829,9 @@ func (m Model) browseTitleWidth() int {
 		termWidth = ui.DefaultWidth
 	}
 	contentWidth := ui.ReaderContentWidth(termWidth)
-	numWidth := len(fmt.Sprintf("%d", len(m.allItems)))
-	prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, len(m.allItems)))
+	itemCount := len(m.searchItems())
+	numWidth := len(fmt.Sprintf("%d", itemCount))
+	prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, itemCount))
 	dateLayout := ui.ResolveDateLayout(contentWidth, prefixWidth)
 	titleWidth := contentWidth - prefixWidth - dateLayout.ColumnWidth
 	minWidth := prefixWidth + dateLayout.ColumnWidth + ui.MinTitleWidth
@@ -675,7 +845,7 @@ func (m Model) browseTitleWidth() int {
 }
 
 func browseItemHeight(item rss.Item, titleWidth, titleLines, subtitleLines int) int {
-	title := item.CleanTitle()
+	title := ui.BadgedTitle(ui.SectionLabel(item.Section), item.CleanTitle())
 	titleLineCount := len(ui.LimitLines(ui.WrapLines(title, titleWidth), titleLines, titleWidth))
 	if titleLineCount == 0 {
 		titleLineCount = 1
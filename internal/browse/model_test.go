@@ -1,9 +1,19 @@
 package browse
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/annotations"
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
+	"github.com/tmustier/economist-tui/internal/history"
+	"github.com/tmustier/economist-tui/internal/readstate"
 	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/search"
+	"github.com/tmustier/economist-tui/internal/ui"
 )
 
 func TestResolveSectionIndexUsesPrimaryAlias(t *testing.T) {
@@ -47,3 +57,383 @@ func TestQueueSectionChangeUsesPendingIndex(t *testing.T) {
 		t.Fatalf("expected sectionLoading to be true")
 	}
 }
+
+func TestFollowLinkAndBack(t *testing.T) {
+	current := &article.Article{
+		Title: "Current",
+		Blocks: []article.Block{{Kind: article.BlockParagraph, Text: "See more and elsewhere.", Spans: []article.Span{
+			{Text: "See "},
+			{Text: "more", URL: "https://www.economist.com/briefing/more"},
+			{Text: " and "},
+			{Text: "elsewhere", URL: "https://example.org/"},
+			{Text: "."},
+		}}},
+	}
+	m := Model{mode: modeArticle, article: current, width: 80, height: 30, scroll: 0}
+
+	next, _ := m.updateArticle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = next.(Model)
+	if !m.linkPicker || len(m.links) != 2 {
+		t.Fatalf("expected link picker with 2 links, got %v %#v", m.linkPicker, m.links)
+	}
+
+	next, _ = m.updateArticle(tea.KeyMsg{Type: tea.KeyDown})
+	m = next.(Model)
+	next, _ = m.updateArticle(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if m.articleStatus == "" || len(m.articleTrail) != 0 {
+		t.Fatalf("expected external link status, got %q", m.articleStatus)
+	}
+
+	next, _ = m.updateArticle(tea.KeyMsg{Type: tea.KeyUp})
+	m = next.(Model)
+	next, cmd := m.updateArticle(tea.KeyMsg{Type: tea.KeyEnter})
+	m = next.(Model)
+	if cmd == nil || !m.loading || m.pendingURL != "https://www.economist.com/briefing/more" {
+		t.Fatalf("expected fetch of linked article, got loading=%v url=%q", m.loading, m.pendingURL)
+	}
+	if len(m.articleTrail) != 1 || m.linkPicker {
+		t.Fatalf("expected one trail frame and closed picker")
+	}
+
+	next, _ = m.updateArticle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = next.(Model)
+	if m.mode != modeArticle || m.article != current || m.loading {
+		t.Fatalf("expected back to return to the previous article")
+	}
+
+	next, _ = m.updateArticle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = next.(Model)
+	if m.mode != modeBrowse {
+		t.Fatalf("expected second back to return to the list")
+	}
+}
+
+func TestQuitFromLinkPickerSavesProgress(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var body strings.Builder
+	for i := 0; i < 40; i++ {
+		body.WriteString("A paragraph long enough to wrap across more than one line of the reader at most widths.\n\n")
+	}
+	blocks := append(article.ParagraphBlocks(body.String()), article.Block{Kind: article.BlockParagraph, Text: "See more.", Spans: []article.Span{
+		{Text: "See "},
+		{Text: "more", URL: "https://www.economist.com/briefing/more"},
+		{Text: "."},
+	}})
+	art := &article.Article{URL: "https://www.economist.com/briefing/long", Title: "Long", Blocks: blocks}
+	if _, err := history.Opened(art.URL, art.Title, "briefing"); err != nil {
+		t.Fatalf("record opened: %v", err)
+	}
+	m := Model{mode: modeArticle, loading: true, pendingURL: art.URL, width: 100, height: 30, opts: Options{NoColor: true}}
+	next, _ := m.Update(articleMsg{url: art.URL, article: art})
+	m = next.(Model)
+	m.scroll = m.maxArticleScroll() / 2
+
+	next, _ = m.updateArticle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = next.(Model)
+	if !m.linkPicker {
+		t.Fatalf("expected the link picker to open")
+	}
+	_, cmd := m.updateArticle(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatalf("expected a quit command")
+	}
+
+	// tea.Sequence wraps its commands in an unexported slice message.
+	msg := cmd()
+	if _, ok := msg.(tea.QuitMsg); ok {
+		t.Fatalf("expected progress saved before quitting")
+	}
+	seq := reflect.ValueOf(msg)
+	if seq.Kind() != reflect.Slice {
+		t.Fatalf("expected a command sequence, got %T", msg)
+	}
+	quits := false
+	for i := 0; i < seq.Len(); i++ {
+		step, ok := seq.Index(i).Interface().(tea.Cmd)
+		if !ok || step == nil {
+			continue
+		}
+		if _, ok := step().(tea.QuitMsg); ok {
+			quits = true
+		}
+	}
+	if !quits {
+		t.Fatalf("expected the sequence to end in a quit")
+	}
+
+	entry, ok, err := history.Lookup(art.URL)
+	if err != nil || !ok || entry.Scroll < 0.4 || entry.Scroll > 0.6 {
+		t.Fatalf("expected progress saved half way, got %#v %v %v", entry, ok, err)
+	}
+}
+
+func TestFullTextSearchMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	err := search.IndexArticle(&article.Article{
+		URL:    "https://www.economist.com/americas/argentina",
+		Title:  "Argentina restructures again",
+		Blocks: article.ParagraphBlocks("The government wants to restructure its bonds once more."),
+	})
+	if err != nil {
+		t.Fatalf("index: %v", err)
+	}
+
+	items := []rss.Item{{Title: "Unrelated headline", Link: "https://www.economist.com/leaders/other"}}
+	m := Model{allItems: items, filteredItems: items, width: 80, height: 30}
+
+	next, _ := m.updateBrowse(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = next.(Model)
+	if !m.fullText || len(m.filteredItems) != 0 {
+		t.Fatalf("expected empty full-text mode, got fullText=%v items=%d", m.fullText, len(m.filteredItems))
+	}
+
+	next, _ = m.updateBrowse(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("bond")})
+	m = next.(Model)
+	if len(m.filteredItems) != 1 || m.filteredItems[0].Link != "https://www.economist.com/americas/argentina" {
+		t.Fatalf("expected one full-text hit, got %#v", m.filteredItems)
+	}
+	if words := m.hitWords[m.filteredItems[0].Link]; len(words) != 1 || words[0] != "bonds" {
+		t.Fatalf("expected highlighted snippet word, got %#v", words)
+	}
+
+	next, _ = m.updateBrowse(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(Model)
+	next, _ = m.updateBrowse(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(Model)
+	if m.fullText || len(m.filteredItems) != 1 || m.filteredItems[0].Title != "Unrelated headline" {
+		t.Fatalf("expected esc to return to the section list")
+	}
+}
+
+type fakeSource struct {
+	sections map[string][]rss.Item
+}
+
+func (s fakeSource) Section(section string) (string, []rss.Item, error) {
+	return section, s.sections[section], nil
+}
+
+func (s fakeSource) Article(url string) (*article.Article, error) {
+	return nil, nil
+}
+
+func TestAllSectionsSearch(t *testing.T) {
+	source := fakeSource{sections: map[string][]rss.Item{
+		"leaders": {{Title: "China and the world", GUID: "a"}},
+		"china":   {{Title: "China and the world", GUID: "a"}, {Title: "Chinese trade", GUID: "b"}},
+	}}
+	m := Model{
+		allItems:      source.sections["leaders"],
+		filteredItems: source.sections["leaders"],
+		sections:      []rss.SectionInfo{{Primary: "leaders"}, {Primary: "china"}},
+		source:        source,
+		width:         80,
+		height:        30,
+	}
+
+	next, cmd := m.updateBrowse(tea.KeyMsg{Type: tea.KeyCtrlA})
+	m = next.(Model)
+	if !m.allSections || cmd == nil {
+		t.Fatalf("expected all-sections mode to load feeds")
+	}
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	if len(m.filteredItems) != 2 {
+		t.Fatalf("expected 2 merged items, got %d", len(m.filteredItems))
+	}
+
+	next, _ = m.updateBrowse(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("trade")})
+	m = next.(Model)
+	if len(m.filteredItems) != 1 || m.filteredItems[0].Section != "china" {
+		t.Fatalf("expected one hit from china, got %#v", m.filteredItems)
+	}
+	if view := m.View(); !strings.Contains(view, "[China]") {
+		t.Fatalf("expected section badge in view")
+	}
+}
+
+func TestOpeningItemMarksItRead(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	items := []rss.Item{
+		{Title: "First", Link: "https://www.economist.com/leaders/one", GUID: "one"},
+		{Title: "Second", Link: "https://www.economist.com/leaders/two", GUID: "two"},
+	}
+	state := readstate.New()
+	m := NewModel("leaders", items, "Leaders", Options{ReadState: state}, fakeSource{})
+	styles := ui.NewBrowseStyles(true)
+
+	if got := ui.StripANSI(m.renderSectionDots(styles, 200)); !strings.Contains(got, "●2") {
+		t.Fatalf("expected 2 unread on the current section, got %q", got)
+	}
+
+	m, cmd := m.openItem(1)
+	if cmd == nil || !state.IsRead("two") || state.IsRead("one") {
+		t.Fatalf("expected opening to mark only the second item read")
+	}
+	if got := ui.StripANSI(m.renderSectionDots(styles, 200)); !strings.Contains(got, "●1") {
+		t.Fatalf("expected 1 unread after opening, got %q", got)
+	}
+	if got := ui.StripANSI(m.renderSectionDots(styles, 10)); !strings.Contains(got, "1 unread") {
+		t.Fatalf("expected compact dots to show the unread count, got %q", got)
+	}
+
+	if _, cmd := m.openItem(1); cmd == nil {
+		t.Fatalf("expected reopening to still fetch the article")
+	}
+}
+
+func TestArticleScrollResumesProportionally(t *testing.T) {
+	var body strings.Builder
+	for i := 0; i < 40; i++ {
+		body.WriteString("A paragraph long enough to wrap across more than one line of the reader at most widths.\n\n")
+	}
+	art := &article.Article{URL: "https://www.economist.com/briefing/long", Title: "Long", Blocks: article.ParagraphBlocks(body.String())}
+	m := Model{mode: modeArticle, loading: true, pendingURL: art.URL, width: 100, height: 30, opts: Options{NoColor: true, Ephemeral: true}}
+
+	next, _ := m.Update(articleMsg{url: art.URL, article: art, resume: 0.5})
+	m = next.(Model)
+	maxScroll := m.maxArticleScroll()
+	if maxScroll == 0 || m.scroll != maxScroll/2 && m.scroll != (maxScroll+1)/2 {
+		t.Fatalf("expected to resume half way, got scroll %d of %d", m.scroll, maxScroll)
+	}
+
+	next, _ = m.Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	m = next.(Model)
+	fraction, completed := m.articleProgress()
+	if completed || fraction < 0.45 || fraction > 0.55 {
+		t.Fatalf("expected position kept across a resize, got %.2f", fraction)
+	}
+	if m.saveProgressCmd() != nil {
+		t.Fatalf("expected no history recorded for an ephemeral session")
+	}
+}
+
+func TestBookmarkToggleAndSavedSection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	items := []rss.Item{{Title: "Bonds wobble", Link: "https://www.economist.com/finance/bonds"}}
+	m := NewModel("finance", items, "Finance", Options{}, nil)
+
+	last := m.sections[len(m.sections)-1]
+	if last.Primary != bookmarks.SectionName {
+		t.Fatalf("expected Saved as the last section, got %q", last.Primary)
+	}
+
+	next, cmd := m.updateBrowse(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatalf("expected a bookmark command")
+	}
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	if m.listStatus != bookmarkAddedStatus || !bookmarks.Contains(items[0].Link) {
+		t.Fatalf("expected the headline bookmarked, status %q", m.listStatus)
+	}
+
+	title, saved, err := fetchSection(rssSource{}, bookmarks.SectionName)
+	if err != nil || title != "Saved" || len(saved) != 1 || saved[0].Section != "finance" {
+		t.Fatalf("expected the bookmark in Saved, got %q %#v %v", title, saved, err)
+	}
+
+	m = NewModel(bookmarks.SectionName, saved, title, Options{}, nil)
+	next, cmd = m.updateBrowse(tea.KeyMsg{Type: tea.KeyCtrlB})
+	m = next.(Model)
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	if m.listStatus != bookmarkRemovedStatus || len(m.filteredItems) != 0 {
+		t.Fatalf("expected removal to drop the item from Saved, got %q %#v", m.listStatus, m.filteredItems)
+	}
+}
+
+func TestParagraphHighlightAndNote(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	art := &article.Article{
+		URL:    "https://www.economist.com/finance/bonds",
+		Title:  "Bonds",
+		Blocks: article.ParagraphBlocks("Yields rose.\n\nInvestors fled.\n\nCentral banks hesitated."),
+	}
+	m := Model{mode: modeArticle, loading: true, pendingURL: art.URL, width: 100, height: 40, opts: Options{NoColor: true}}
+	next, _ := m.Update(articleMsg{url: art.URL, article: art, annotations: loadAnnotations(art, false)})
+	m = next.(Model)
+
+	press := func(keys ...tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+		for _, key := range keys {
+			next, cmd = m.Update(key)
+			m = next.(Model)
+		}
+		return cmd
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	press(runes("p"), runes("j"))
+	if !m.paraMode || m.paraBlock != 1 {
+		t.Fatalf("expected the cursor on the second paragraph, got mode=%v block=%d", m.paraMode, m.paraBlock)
+	}
+	if view := m.View(); !strings.Contains(view, ui.ParagraphCursorMarker+"Investors fled.") {
+		t.Fatalf("expected the cursor marker in the margin:\n%s", view)
+	}
+
+	press(runes("h"))
+	cmd := press(runes("n"), runes("Panic"), tea.KeyMsg{Type: tea.KeySpace}, runes("selling"), tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected the note to be saved")
+	}
+	cmd()
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.paraMode || !strings.Contains(m.View(), "✎ Investors fled.") {
+		t.Fatalf("expected the note marker after leaving paragraph mode:\n%s", m.View())
+	}
+
+	doc, ok, err := annotations.Lookup(art.URL)
+	if err != nil || !ok || len(doc.Annotations) != 1 {
+		t.Fatalf("expected a saved annotation, got %#v (ok=%v err=%v)", doc, ok, err)
+	}
+	if a := doc.Annotations[0]; a.Quote != "Investors fled." || !a.Highlight || a.Note != "Panic selling" {
+		t.Fatalf("unexpected annotation: %#v", a)
+	}
+}
+
+type prefetchSource struct {
+	fakeSource
+	prefetched *[]string
+}
+
+func (s prefetchSource) PrefetchArticle(url string) {
+	*s.prefetched = append(*s.prefetched, url)
+}
+
+func TestCursorRestPrefetchesArticle(t *testing.T) {
+	items := []rss.Item{
+		{Title: "First", Link: "https://www.economist.com/leaders/one"},
+		{Title: "Second", Link: "https://www.economist.com/leaders/two"},
+	}
+	var prefetched []string
+	m := Model{
+		allItems:      items,
+		filteredItems: items,
+		sections:      []rss.SectionInfo{{Primary: "leaders"}},
+		source:        prefetchSource{prefetched: &prefetched},
+		width:         80,
+		height:        30,
+	}
+
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatalf("expected moving the cursor to schedule a prefetch")
+	}
+
+	// The cursor moved on before the delay ran out: nothing is fetched.
+	if _, cmd = m.Update(prefetchMsg{url: items[0].Link}); cmd != nil {
+		t.Fatalf("expected no prefetch for a headline the cursor left")
+	}
+	_, cmd = m.Update(prefetchMsg{url: items[1].Link})
+	if cmd == nil {
+		t.Fatalf("expected a prefetch for the headline under the cursor")
+	}
+	cmd()
+	if len(prefetched) != 1 || prefetched[0] != items[1].Link {
+		t.Fatalf("expected the second article prefetched, got %v", prefetched)
+	}
+}
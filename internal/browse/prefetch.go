@@ -0,0 +1,48 @@
+package browse
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prefetchDelay is how long the cursor rests on a headline before its
+// article is fetched ahead, so scrolling past does not fetch everything.
+const prefetchDelay = 400 * time.Millisecond
+
+// prefetchMsg fires once the cursor may have rested on url.
+type prefetchMsg struct {
+	url string
+}
+
+// cursorURL returns the link under the browse cursor, if any.
+func (m Model) cursorURL() string {
+	if m.cursor < 0 || m.cursor >= len(m.filteredItems) {
+		return ""
+	}
+	return strings.TrimSpace(m.filteredItems[m.cursor].Link)
+}
+
+// schedulePrefetch waits for the cursor to rest on url, when the source
+// can prefetch at all.
+func (m Model) schedulePrefetch(url string) tea.Cmd {
+	if _, ok := m.source.(ArticlePrefetcher); !ok || url == "" {
+		return nil
+	}
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return prefetchMsg{url: url}
+	})
+}
+
+// updatePrefetch prefetches the article if the cursor is still on it.
+func (m Model) updatePrefetch(msg prefetchMsg) (tea.Model, tea.Cmd) {
+	prefetcher, ok := m.source.(ArticlePrefetcher)
+	if !ok || m.mode != modeBrowse || m.cursorURL() != msg.url {
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		prefetcher.PrefetchArticle(msg.url)
+		return nil
+	}
+}
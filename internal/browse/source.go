@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/bookmarks"
 	"github.com/tmustier/economist-tui/internal/fetch"
 	"github.com/tmustier/economist-tui/internal/rss"
 )
@@ -13,6 +14,40 @@ type DataSource interface {
 	Article(url string) (*article.Article, error)
 }
 
+// ArticlePrefetcher is implemented by sources that can fetch an article
+// ahead of its being opened.
+type ArticlePrefetcher interface {
+	PrefetchArticle(url string)
+}
+
+// SectionLister is implemented by sources that offer their own sections
+// instead of the RSS section list.
+type SectionLister interface {
+	Sections() []rss.SectionInfo
+}
+
+// sourceSections returns the sections browse cycles through: the source's
+// own, or the feed sections followed by the Saved bookmarks.
+func sourceSections(source DataSource, opts Options) []rss.SectionInfo {
+	if lister, ok := source.(SectionLister); ok {
+		return lister.Sections()
+	}
+	sections := rss.SectionList()
+	if !opts.Ephemeral {
+		sections = append(sections, bookmarks.SectionInfo())
+	}
+	return sections
+}
+
+// fetchSection loads a section from the source, serving the Saved
+// pseudo-section from the bookmarks.
+func fetchSection(source DataSource, section string) (string, []rss.Item, error) {
+	if _, ok := source.(SectionLister); !ok && section == bookmarks.SectionName {
+		return bookmarks.Section()
+	}
+	return source.Section(section)
+}
+
 type rssSource struct {
 	debug bool
 }
@@ -28,3 +63,7 @@ func (s rssSource) Section(section string) (string, []rss.Item, error) {
 func (s rssSource) Article(url string) (*article.Article, error) {
 	return fetch.FetchArticle(url, fetch.Options{Debug: s.debug})
 }
+
+func (s rssSource) PrefetchArticle(url string) {
+	fetch.Prefetch([]string{url}, fetch.Options{Debug: s.debug})
+}
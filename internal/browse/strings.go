@@ -1,8 +1,25 @@
 package browse
 
 const (
-	articleHelpFormat      = "b back • ⇧⇥/⇥ prev/next • c columns %s • ↑/↓ scroll • q quit"
 	articleLoadingHelp     = "b back • ⇧⇥/⇥ prev/next • q quit"
+	linkPickerHelp         = "↑/↓ select • ↵ open • esc close"
+	paragraphHelp          = "↑/↓ paragraph • h highlight • n note • x clear • esc done"
+	noteInputHelp          = "type a note • ↵ save • esc cancel"
+	linkPickerTitle        = "Links in this article"
+	linkExternalLabel      = "external"
+	articleSavedStatus     = "saved to library"
+	bookmarkAddedStatus    = "bookmarked"
+	bookmarkRemovedStatus  = "bookmark removed"
+	fullTextLimit          = 50
+	allSectionsTitle       = "All sections"
+	frontPageTitle         = "Front page"
+	frontPageItems         = 3
+	frontPagePrefixWidth   = 2
+	frontPageHeaderLines   = 4
+	frontPageSectionLines  = 2
+	frontPageFooterLines   = 3
+	linkPickerHeaderLines  = 3
+	linkPickerItemLines    = 3
 	browseTitleLines       = 2
 	browseSubtitleLines    = 2
 	browseHeaderLines      = 5
@@ -0,0 +1,49 @@
+package browse
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+// markRead records the item as opened and returns a command saving the
+// read state, or nil when nothing changed.
+func (m Model) markRead(item rss.Item) tea.Cmd {
+	state := m.opts.ReadState
+	if !state.MarkRead(item.Key()) {
+		return nil
+	}
+	debug := m.opts.Debug
+	return func() tea.Msg {
+		if err := state.Save(); err != nil {
+			logging.Debugf(debug, "browse: save read state: %v", err)
+		}
+		return nil
+	}
+}
+
+// rememberSection keeps a section's items so its unread count can be
+// shown after moving on to other sections.
+func (m *Model) rememberSection(section string, items []rss.Item) {
+	if m.sectionItems == nil {
+		m.sectionItems = make(map[string][]rss.Item)
+	}
+	m.sectionItems[section] = items
+}
+
+// sectionUnread returns the number of unread items in the section at
+// index, and false when the section has not been loaded yet.
+func (m Model) sectionUnread(index int) (int, bool) {
+	if m.opts.ReadState == nil || index < 0 || index >= len(m.sections) {
+		return 0, false
+	}
+	items, ok := m.sectionItems[m.sections[index].Primary]
+	if !ok {
+		return 0, false
+	}
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = item.Key()
+	}
+	return m.opts.ReadState.UnreadCount(keys), true
+}
@@ -3,8 +3,11 @@ package browse
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"github.com/tmustier/economist-tui/internal/ui"
 )
 
@@ -31,7 +34,11 @@ func (m Model) browseView() (string, string) {
 	indent := ui.ArticleIndent(ui.ArticleRenderOptions{TermWidth: termWidth, WrapWidth: contentWidth, Center: true})
 
 	b.WriteString("\n")
-	header := styles.Header.Render(m.sectionTitle)
+	title := m.sectionTitle
+	if m.allSections {
+		title = allSectionsTitle
+	}
+	header := styles.Header.Render(title)
 	b.WriteString(header + "\n")
 	b.WriteString(ui.AccentRule(contentWidth, accentStyles) + "\n")
 
@@ -39,8 +46,12 @@ func (m Model) browseView() (string, string) {
 	statusLine := ""
 	if m.sectionLoading && m.pendingSection != "" {
 		statusLine = styles.Dim.Render(fmt.Sprintf("loading %s…", m.pendingSection))
+	} else if m.allSectionsLoading {
+		statusLine = styles.Dim.Render("loading all sections…")
 	} else if m.sectionErr != nil {
 		statusLine = styles.Dim.Render(fmt.Sprintf("error: %v", m.sectionErr))
+	} else if m.listStatus != "" {
+		statusLine = styles.Dim.Render(m.listStatus)
 	}
 
 	// Search bar with states: idle, active, no-match
@@ -55,7 +66,11 @@ func (m Model) browseView() (string, string) {
 	layout := browseLayout{}
 
 	if len(items) == 0 {
-		b.WriteString("\n" + styles.Dim.Render("  No matching articles") + "\n")
+		empty := "  No matching articles"
+		if m.fullText && strings.TrimSpace(m.searchQuery) == "" {
+			empty = fmt.Sprintf("  Search %d articles you have read", m.searchIndex.Len())
+		}
+		b.WriteString("\n" + styles.Dim.Render(empty) + "\n")
 	} else {
 		layout = m.browseLayout(len(items))
 		maxVisible = layout.maxVisible
@@ -79,8 +94,9 @@ func (m Model) browseView() (string, string) {
 			end = len(items)
 		}
 
-		numWidth := ui.Max(2, len(fmt.Sprintf("%d", len(m.allItems))))
-		prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, len(m.allItems)))
+		itemCount := len(m.searchItems())
+		numWidth := ui.Max(2, len(fmt.Sprintf("%d", itemCount)))
+		prefixWidth := len(fmt.Sprintf("%*d. ", numWidth, itemCount))
 		dateLayout := ui.ResolveDateLayout(contentWidth, prefixWidth)
 
 		listItems := make([]ui.ListItem, len(items))
@@ -90,9 +106,12 @@ func (m Model) browseView() (string, string) {
 				date = item.CompactDate()
 			}
 			listItems[i] = ui.ListItem{
-				Title:    item.CleanTitle(),
-				Subtitle: item.CleanDescription(),
-				Right:    date,
+				Title:      item.CleanTitle(),
+				Subtitle:   item.CleanDescription(),
+				Right:      date,
+				Badge:      ui.SectionLabel(item.Section),
+				Highlights: m.hitWords[item.Link],
+				Read:       m.opts.ReadState.IsRead(item.Key()),
 			}
 		}
 
@@ -116,6 +135,9 @@ func (m Model) browseView() (string, string) {
 			Selected:      styles.Selected,
 			Right:         styles.Dim,
 			RightSelected: styles.Selected,
+			Highlight:     styles.Match,
+			Badge:         styles.Badge,
+			Read:          styles.Read,
 		}
 
 		b.WriteString(ui.RenderList(listItems, listOpts, listStyles))
@@ -201,6 +223,20 @@ func (m Model) articleView() (string, string) {
 		return ui.PadBlockRight(content, padWidth), ui.PadBlockRight(footer, padWidth)
 	}
 
+	if m.linkPicker {
+		content := m.linkPickerView(styles)
+		statusLine := ""
+		if m.articleStatus != "" {
+			statusLine = ui.CenterText(styles.Dim.Render(m.articleStatus), contentWidth)
+		}
+		centeredHelp := ui.CenterText(styles.Help.Render(linkPickerHelp), contentWidth)
+		footer := ui.BuildFooter(divider, statusLine, centeredHelp)
+		if indent > 0 {
+			footer = ui.IndentBlock(footer, indent)
+		}
+		return ui.PadBlockRight(content, padWidth), ui.PadBlockRight(footer, padWidth)
+	}
+
 	if len(m.articleLines) == 0 {
 		b.WriteString("No article loaded.")
 		content := b.String()
@@ -222,7 +258,10 @@ func (m Model) articleView() (string, string) {
 	if m.twoColumn {
 		columnLabel = "on"
 	}
-	help := fmt.Sprintf(articleHelpFormat, columnLabel)
+	help := articleHelpLine(contentWidth, columnLabel)
+	if m.paraMode {
+		help = m.paragraphHelp()
+	}
 
 	showMore := end < len(m.articleLines)
 	hintLine := ""
@@ -239,6 +278,14 @@ func (m Model) articleView() (string, string) {
 		}
 		hintLine = styles.Dim.Render(fmt.Sprintf("%d%% · more ↓", pct))
 	}
+	if m.paraMode {
+		if hint := m.paragraphHint(); hint != "" {
+			hintLine = styles.Dim.Render(hint)
+		}
+	}
+	if m.articleStatus != "" {
+		hintLine = styles.Dim.Render(m.articleStatus)
+	}
 
 	lastLine := lastNonBlankLine(m.articleLines[start:end])
 	if ui.IsRuleLine(lastLine) {
@@ -287,7 +334,8 @@ func lastNonBlankLine(lines []string) string {
 }
 
 // renderSectionDots renders section position dots with tab navigation hints.
-// Format: ⇧⇥  ○ ○ ● ○ ○  ⇥
+// Loaded sections with unread headlines show the count after their dot.
+// Format: ⇧⇥  ○ ○3 ● ○ ○  ⇥
 func (m Model) renderSectionDots(styles ui.BrowseStyles, width int) string {
 	const (
 		tabIcon      = "⇥"
@@ -298,26 +346,31 @@ func (m Model) renderSectionDots(styles ui.BrowseStyles, width int) string {
 	)
 
 	numSections := len(m.sections)
-	showAllDots := numSections <= maxDots && numSections*2-1+10 <= width // dots + spacing + icons
-
-	var content string
-	if showAllDots {
-		// Build dots: ○ ○ ● ○ ○
-		var dots strings.Builder
-		for i := 0; i < numSections; i++ {
-			if i > 0 {
-				dots.WriteString(" ")
-			}
-			if i == m.sectionIndex {
-				dots.WriteString(dotActive)
-			} else {
-				dots.WriteString(dotInactive)
-			}
+
+	// Build dots: ○ ○3 ● ○ ○
+	var dots strings.Builder
+	for i := 0; i < numSections; i++ {
+		if i > 0 {
+			dots.WriteString(" ")
 		}
-		content = fmt.Sprintf("%s  %s  %s", shiftTabIcon, dots.String(), tabIcon)
-	} else {
-		// Compact: ⇧⇥  3/12  ⇥
-		content = fmt.Sprintf("%s  %d/%d  %s", shiftTabIcon, m.sectionIndex+1, numSections, tabIcon)
+		if i == m.sectionIndex {
+			dots.WriteString(dotActive)
+		} else {
+			dots.WriteString(dotInactive)
+		}
+		if unread, ok := m.sectionUnread(i); ok && unread > 0 {
+			dots.WriteString(strconv.Itoa(unread))
+		}
+	}
+	content := fmt.Sprintf("%s  %s  %s", shiftTabIcon, dots.String(), tabIcon)
+
+	if numSections > maxDots || lipgloss.Width(content) > width {
+		// Compact: ⇧⇥  3/12 · 5 unread  ⇥
+		position := fmt.Sprintf("%d/%d", m.sectionIndex+1, numSections)
+		if unread, ok := m.sectionUnread(m.sectionIndex); ok && unread > 0 {
+			position = fmt.Sprintf("%s · %d unread", position, unread)
+		}
+		content = fmt.Sprintf("%s  %s  %s", shiftTabIcon, position, tabIcon)
 	}
 
 	return styles.Dim.Render(content)
@@ -331,12 +384,26 @@ func (m Model) renderSearchBar(styles ui.BrowseStyles, width int) string {
 
 	if m.searchQuery == "" {
 		// Idle state: show placeholder
+		if m.fullText {
+			return styles.SearchIdle.Render("/ full-text search... (esc to leave)")
+		}
+		if m.allSections {
+			return styles.SearchIdle.Render("/ search all sections... (esc to leave)")
+		}
 		return styles.SearchIdle.Render("/ type to filter...")
 	}
 
 	// Active state with query
-	totalItems := len(m.allItems)
+	totalItems := len(m.searchItems())
 	matchCount := len(m.filteredItems)
+	if m.fullText {
+		totalItems = m.searchIndex.Len()
+	}
+
+	if m.searchErr != nil {
+		text := fmt.Sprintf("%s %s%s  %v", prefix, m.searchQuery, cursor, m.searchErr)
+		return styles.SearchNoMatch.Render(text)
+	}
 
 	if matchCount == 0 {
 		// No match state
@@ -77,10 +77,10 @@ func SharedHeadlessContext(debug bool) context.Context {
 	sharedMu.Lock()
 	defer sharedMu.Unlock()
 
-	if sharedCtx == nil || sharedDebug != debug {
-		if sharedCancel != nil {
-			sharedCancel()
-		}
+	if sharedCtx != nil && (sharedDebug != debug || Lost(sharedCtx)) {
+		closeShared()
+	}
+	if sharedCtx == nil {
 		sharedCtx, sharedCancel = newHeadlessContext(context.Background(), debug, "")
 		sharedDebug = debug
 	}
@@ -92,7 +92,10 @@ func SharedHeadlessContext(debug bool) context.Context {
 func CloseSharedHeadless() {
 	sharedMu.Lock()
 	defer sharedMu.Unlock()
+	closeShared()
+}
 
+func closeShared() {
 	if sharedCancel != nil {
 		sharedCancel()
 		sharedCancel = nil
@@ -100,6 +103,27 @@ func CloseSharedHeadless() {
 	}
 }
 
+// Lost reports whether the browser behind ctx has gone: the context was
+// cancelled, or Chrome died (crashed, OOM-killed) and dropped its
+// connection. chromedp does not cancel the context when Chrome dies, so
+// checking ctx.Err() alone misses a crash. A context whose browser has
+// not started yet is not lost.
+func Lost(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Browser == nil {
+		return false
+	}
+	select {
+	case <-c.Browser.LostConnection:
+		return true
+	default:
+		return false
+	}
+}
+
 // VisibleContext creates a visible browser context for interactive login.
 func VisibleContext(ctx context.Context, userDataDir string) (context.Context, context.CancelFunc) {
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
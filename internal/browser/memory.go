@@ -0,0 +1,54 @@
+package browser
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ProcessMemory returns the resident memory, in bytes, of the processes
+// and everything they started. Chrome runs each renderer in a child
+// process, so the browser's own figure is a fraction of what it holds.
+func ProcessMemory(pids ...int) (int64, error) {
+	out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,rss=").Output()
+	if err != nil {
+		return 0, err
+	}
+	return treeMemory(string(out), pids), nil
+}
+
+// treeMemory sums the RSS column of ps output (in KiB) over the roots and
+// their descendants.
+func treeMemory(psOutput string, roots []int) int64 {
+	children := make(map[int][]int)
+	rss := make(map[int]int64)
+	for _, line := range strings.Split(psOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		kb, err3 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		children[ppid] = append(children[ppid], pid)
+		rss[pid] = kb
+	}
+
+	var total int64
+	seen := make(map[int]bool)
+	queue := append([]int(nil), roots...)
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		total += rss[pid] * 1024
+		queue = append(queue, children[pid]...)
+	}
+	return total
+}
@@ -0,0 +1,557 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	DefaultPoolSize     = 4
+	DefaultTabMaxUses   = 50
+	tabHealthTimeout    = 2 * time.Second
+	tabSetupTimeout     = 30 * time.Second
+	browserStartTimeout = 15 * time.Second
+	defaultWarmPageURL  = "about:blank"
+
+	// RelaunchTimeout bounds replacing a dead browser: starting Chrome and
+	// setting up a fresh tab on it.
+	RelaunchTimeout = browserStartTimeout + tabSetupTimeout
+)
+
+// ErrPoolClosed is returned by Acquire once the pool is closed.
+var ErrPoolClosed = errors.New("tab pool closed")
+
+// PoolOptions configures a TabPool. Zero values take the defaults.
+type PoolOptions struct {
+	Size    int // tabs open at once
+	MaxUses int // fetches before a tab is closed and replaced
+	// WarmURL is loaded into each new tab before it is handed out, so the
+	// first fetch on it finds the site's scripts cached.
+	WarmURL string
+	// Setup prepares a new tab before it is warmed, e.g. network blocking.
+	Setup func(ctx context.Context) error
+	Debug bool
+}
+
+// Tab is a browser tab leased from a TabPool. Run chromedp actions on its
+// Context and hand it back with Release.
+type Tab struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	browser context.Context // the browser the tab was opened on
+	uses    int
+}
+
+// Context returns the tab's chromedp context.
+func (t *Tab) Context() context.Context {
+	return t.ctx
+}
+
+// BrowserLost reports whether the browser the tab lives on has died, so a
+// failed fetch on it is worth retrying on a fresh tab.
+func (t *Tab) BrowserLost() bool {
+	return t.browser != nil && Lost(t.browser)
+}
+
+// PoolStats is a snapshot of a pool's tabs.
+type PoolStats struct {
+	Size     int `json:"size"`
+	Open     int `json:"open"`
+	Idle     int `json:"idle"`
+	Busy     int `json:"busy"`
+	Served   int `json:"served"`
+	Recycled int `json:"recycled"`
+	Restarts int `json:"restarts"` // browsers relaunched after a crash
+	// BrowserTabs counts the tabs opened on the current browser, and
+	// BrowserRecycles the browsers replaced by Recycle.
+	BrowserTabs     int  `json:"browser_tabs"`
+	BrowserRecycles int  `json:"browser_recycles"`
+	Retiring        int  `json:"retiring,omitempty"` // recycled browsers with tabs still leased
+	Starting        bool `json:"starting,omitempty"` // a browser is being launched
+}
+
+// TabPool keeps a bounded set of warm tabs on one headless browser and
+// leases them out one request at a time. Idle tabs are health-checked
+// before reuse, tabs are replaced after MaxUses fetches or a failure, and
+// a watchdog relaunches the browser when Chrome dies.
+type TabPool struct {
+	opts PoolOptions
+
+	// slots holds a token per tab in use (or being warmed), capping them at
+	// Size. Idle tabs hold none.
+	slots chan struct{}
+
+	mu            sync.Mutex
+	idle          []*Tab
+	open          int
+	served        int
+	recycled      int
+	restarts      int
+	closed        bool
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+	browserTabs   int
+	recycles      int
+	// starting is set while a browser launches, outside p.mu, and closed
+	// once it is up or has failed; callers needing a browser wait on it.
+	starting chan struct{}
+
+	// tabsOn counts the open tabs on each browser, so a browser retired by
+	// Recycle is closed once the last of them is back.
+	tabsOn  map[context.Context]int
+	retired map[context.Context]context.CancelFunc
+
+	// newTab, check and launch are swapped out in tests.
+	newTab func() (*Tab, error)
+	check  func(*Tab) error
+	launch func() (context.Context, context.CancelFunc, error)
+}
+
+// NewTabPool returns an empty pool. The browser starts with the first tab;
+// call Warm to open tabs ahead of the first request.
+func NewTabPool(opts PoolOptions) *TabPool {
+	if opts.Size < 1 {
+		opts.Size = DefaultPoolSize
+	}
+	if opts.MaxUses < 1 {
+		opts.MaxUses = DefaultTabMaxUses
+	}
+	if opts.WarmURL == "" {
+		opts.WarmURL = defaultWarmPageURL
+	}
+	p := &TabPool{
+		opts:    opts,
+		slots:   make(chan struct{}, opts.Size),
+		tabsOn:  make(map[context.Context]int),
+		retired: make(map[context.Context]context.CancelFunc),
+	}
+	p.newTab = p.openTab
+	p.check = checkTab
+	p.launch = p.launchBrowser
+	return p
+}
+
+// Acquire leases a healthy tab, waiting while all of them are busy.
+func (p *TabPool) Acquire(ctx context.Context) (*Tab, error) {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	for {
+		tab, err := p.popIdle()
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+		if tab == nil {
+			break
+		}
+		if err := p.check(tab); err == nil {
+			tab.uses++
+			return tab, nil
+		}
+		p.discard(tab)
+	}
+
+	tab, err := p.add()
+	if err != nil {
+		<-p.slots
+		return nil, err
+	}
+	tab.uses++
+	return tab, nil
+}
+
+// Release hands a tab back. A broken tab (one whose fetch failed or timed
+// out) and a tab that has reached MaxUses are closed, and a fresh one is
+// warmed in its place.
+func (p *TabPool) Release(tab *Tab, broken bool) {
+	defer func() { <-p.slots }()
+
+	p.mu.Lock()
+	p.served++
+	_, retired := p.retired[tab.browser]
+	if !broken && tab.uses < p.opts.MaxUses && !p.closed && !retired {
+		p.idle = append(p.idle, tab)
+		p.mu.Unlock()
+		return
+	}
+	closed := p.closed
+	p.recycled++
+	p.mu.Unlock()
+
+	p.discard(tab)
+	if !closed {
+		go p.Warm(1)
+	}
+}
+
+// Warm opens up to n idle tabs, as far as the pool has room. n <= 0 fills
+// the pool.
+func (p *TabPool) Warm(n int) {
+	if n <= 0 {
+		n = p.opts.Size
+	}
+	for range n {
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			return
+		}
+		p.mu.Lock()
+		full := p.closed || p.open >= p.opts.Size
+		p.mu.Unlock()
+		if full {
+			<-p.slots
+			return
+		}
+
+		tab, err := p.add()
+		if err == nil {
+			p.mu.Lock()
+			closed := p.closed
+			if !closed {
+				p.idle = append(p.idle, tab)
+			}
+			p.mu.Unlock()
+			if closed {
+				p.discard(tab)
+			}
+		}
+		<-p.slots
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Stats returns a snapshot of the pool.
+func (p *TabPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return PoolStats{
+		Size:     p.opts.Size,
+		Open:     p.open,
+		Idle:     len(p.idle),
+		Busy:     p.open - len(p.idle),
+		Served:   p.served,
+		Recycled: p.recycled,
+		Restarts: p.restarts,
+
+		BrowserTabs:     p.browserTabs,
+		BrowserRecycles: p.recycles,
+		Retiring:        len(p.retired),
+		Starting:        p.starting != nil,
+	}
+}
+
+// Close closes the idle tabs and the browser. Tabs still leased are closed
+// when released.
+func (p *TabPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	idle := p.idle
+	p.idle = nil
+	cancels := []context.CancelFunc{p.browserCancel}
+	p.browserCtx, p.browserCancel = nil, nil
+	for ctx, cancel := range p.retired {
+		cancels = append(cancels, cancel)
+		delete(p.retired, ctx)
+	}
+	p.mu.Unlock()
+
+	for _, tab := range idle {
+		p.discard(tab)
+	}
+	for _, cancel := range cancels {
+		if cancel != nil {
+			cancel()
+		}
+	}
+}
+
+// Recycle replaces the browser without failing fetches in progress: new
+// tabs open on a fresh browser, idle ones are closed, and the old browser
+// is closed once its last leased tab is released. It gives back the memory
+// a long-running Chrome accumulates.
+func (p *TabPool) Recycle() {
+	p.mu.Lock()
+	old, cancel := p.browserCtx, p.browserCancel
+	if old == nil || p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.browserCtx, p.browserCancel = nil, nil
+	p.browserTabs = 0
+	p.recycles++
+	p.retired[old] = cancel
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, tab := range idle {
+		p.discard(tab)
+	}
+	p.mu.Lock()
+	p.closeRetired(old)
+	p.mu.Unlock()
+	go p.Warm(1)
+}
+
+// MemoryUsage returns the resident memory of the pool's browsers, renderer
+// processes included, in bytes.
+func (p *TabPool) MemoryUsage() (int64, error) {
+	p.mu.Lock()
+	browsers := make([]context.Context, 0, len(p.retired)+1)
+	if p.browserCtx != nil {
+		browsers = append(browsers, p.browserCtx)
+	}
+	for ctx := range p.retired {
+		browsers = append(browsers, ctx)
+	}
+	p.mu.Unlock()
+
+	var pids []int
+	for _, ctx := range browsers {
+		if c := chromedp.FromContext(ctx); c != nil && c.Browser != nil {
+			if proc := c.Browser.Process(); proc != nil {
+				pids = append(pids, proc.Pid)
+			}
+		}
+	}
+	if len(pids) == 0 {
+		return 0, nil
+	}
+	return ProcessMemory(pids...)
+}
+
+// closeRetired closes a retired browser with no tabs left open on it. The
+// caller holds p.mu.
+func (p *TabPool) closeRetired(ctx context.Context) {
+	cancel, ok := p.retired[ctx]
+	if !ok || p.tabsOn[ctx] > 0 {
+		return
+	}
+	delete(p.retired, ctx)
+	cancel()
+}
+
+func (p *TabPool) popIdle() (*Tab, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrPoolClosed
+	}
+	if len(p.idle) == 0 {
+		return nil, nil
+	}
+	// Most recently used first: it is the likeliest to still be healthy.
+	tab := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return tab, nil
+}
+
+// add opens a tab. The caller holds a slot; the tab is counted from the
+// start so a concurrent Warm sees it.
+func (p *TabPool) add() (*Tab, error) {
+	p.mu.Lock()
+	p.open++
+	p.mu.Unlock()
+	tab, err := p.newTab()
+	if err != nil {
+		p.mu.Lock()
+		p.open--
+		p.mu.Unlock()
+		return nil, err
+	}
+	return tab, nil
+}
+
+func (p *TabPool) discard(tab *Tab) {
+	tab.cancel()
+	p.mu.Lock()
+	p.open--
+	if tab.browser != nil && p.tabsOn[tab.browser] > 0 {
+		p.tabsOn[tab.browser]--
+		if p.tabsOn[tab.browser] == 0 {
+			delete(p.tabsOn, tab.browser)
+			p.closeRetired(tab.browser)
+		}
+	}
+	p.mu.Unlock()
+}
+
+// browser returns the pool's browser, starting a new one if there is none
+// or the last one died. Chrome is launched without holding p.mu, so Stats
+// and the daemon's health check answer while it starts; other callers
+// wait for the launch in progress rather than starting a second browser.
+func (p *TabPool) browser() (context.Context, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, ErrPoolClosed
+		}
+		if p.browserCtx != nil && Lost(p.browserCtx) {
+			// The watchdog has not got to it yet; its idle tabs fail their
+			// health check on their own.
+			p.dropBrowser(p.browserCtx)
+		}
+		if p.browserCtx != nil {
+			ctx := p.browserCtx
+			p.mu.Unlock()
+			return ctx, nil
+		}
+		if wait := p.starting; wait != nil {
+			p.mu.Unlock()
+			<-wait
+			continue
+		}
+		done := make(chan struct{})
+		p.starting = done
+		p.mu.Unlock()
+
+		ctx, cancel, err := p.launch()
+
+		p.mu.Lock()
+		p.starting = nil
+		close(done)
+		if err == nil && p.closed {
+			err = ErrPoolClosed
+			cancel()
+		}
+		if err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		p.browserCtx, p.browserCancel = ctx, cancel
+		p.mu.Unlock()
+		go p.watch(ctx)
+		return ctx, nil
+	}
+}
+
+// launchBrowser starts a headless Chrome for the pool, giving up after
+// browserStartTimeout.
+func (p *TabPool) launchBrowser() (context.Context, context.CancelFunc, error) {
+	ctx, cancel := newHeadlessContext(context.Background(), p.opts.Debug, "")
+	// Start the browser on its own context; tabs opened from a context
+	// that has not run yet would each start their own browser. The browser
+	// lives as long as that context, so the start is timed out from here.
+	started := make(chan error, 1)
+	go func() { started <- chromedp.Run(ctx) }()
+	select {
+	case err := <-started:
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		return ctx, cancel, nil
+	case <-time.After(browserStartTimeout):
+		cancel()
+		return nil, nil, fmt.Errorf("chrome did not start within %s", browserStartTimeout)
+	}
+}
+
+// watch waits for the browser to die. Its tabs are gone with it, so the
+// idle ones are dropped and a new browser is started with a fresh tab;
+// tabs still leased fail their fetch and are replaced on release.
+func (p *TabPool) watch(ctx context.Context) {
+	c := chromedp.FromContext(ctx)
+	if c == nil || c.Browser == nil {
+		return
+	}
+	select {
+	case <-ctx.Done():
+		// Closed or replaced on purpose.
+		return
+	case <-c.Browser.LostConnection:
+	}
+
+	p.mu.Lock()
+	dropped := p.dropBrowser(ctx)
+	idle := p.idle
+	if dropped {
+		p.idle = nil
+	}
+	p.mu.Unlock()
+	if !dropped {
+		return
+	}
+
+	log.Printf("tab pool: browser lost, restarting (%d idle tabs dropped)", len(idle))
+	for _, tab := range idle {
+		p.discard(tab)
+	}
+	p.Warm(1)
+}
+
+// dropBrowser tears down ctx's browser and counts a restart, unless ctx is
+// no longer the pool's browser. The caller holds p.mu.
+func (p *TabPool) dropBrowser(ctx context.Context) bool {
+	if p.browserCtx != ctx || p.closed {
+		return false
+	}
+	p.browserCancel()
+	p.browserCtx, p.browserCancel = nil, nil
+	p.browserTabs = 0
+	p.restarts++
+	return true
+}
+
+// openTab opens a tab on the browser, runs Setup and loads WarmURL.
+func (p *TabPool) openTab() (*Tab, error) {
+	browserCtx, err := p.browser()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := chromedp.NewContext(browserCtx)
+	tab := &Tab{ctx: ctx, cancel: cancel, browser: browserCtx}
+	// The tab lives as long as the context of its first run, so open it
+	// before any timeout is applied.
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	setupCtx, setupCancel := context.WithTimeout(ctx, tabSetupTimeout)
+	defer setupCancel()
+	if p.opts.Setup != nil {
+		if err := p.opts.Setup(setupCtx); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	// The warm-up only primes the cache; a tab whose warm page did not load
+	// is still fine to fetch with.
+	if err := chromedp.Run(setupCtx, chromedp.Navigate(p.opts.WarmURL)); err != nil && p.opts.Debug {
+		log.Printf("tab pool: warm %s: %v", p.opts.WarmURL, err)
+	}
+
+	p.mu.Lock()
+	p.tabsOn[browserCtx]++
+	if browserCtx == p.browserCtx {
+		p.browserTabs++
+	}
+	p.mu.Unlock()
+	return tab, nil
+}
+
+// checkTab makes sure an idle tab still answers.
+func checkTab(tab *Tab) error {
+	if err := tab.ctx.Err(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(tab.ctx, tabHealthTimeout)
+	defer cancel()
+	var state string
+	return chromedp.Run(ctx, chromedp.Evaluate(`document.readyState`, &state))
+}
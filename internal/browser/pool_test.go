@@ -0,0 +1,264 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePool returns a pool whose tabs are plain contexts; unhealthy tabs
+// fail the health check.
+func fakePool(opts PoolOptions) (*TabPool, map[*Tab]bool) {
+	p := NewTabPool(opts)
+	var mu sync.Mutex
+	unhealthy := make(map[*Tab]bool)
+	p.newTab = func() (*Tab, error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		return &Tab{ctx: ctx, cancel: cancel}, nil
+	}
+	p.check = func(tab *Tab) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if unhealthy[tab] || tab.ctx.Err() != nil {
+			return errors.New("tab not answering")
+		}
+		return nil
+	}
+	return p, unhealthy
+}
+
+func acquire(t *testing.T, p *TabPool) *Tab {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	tab, err := p.Acquire(ctx)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	return tab
+}
+
+func TestTabPoolBoundsAndReuses(t *testing.T) {
+	p, _ := fakePool(PoolOptions{Size: 2})
+	first := acquire(t, p)
+	second := acquire(t, p)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := p.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a full pool to block, got %v", err)
+	}
+
+	p.Release(first, false)
+	if again := acquire(t, p); again != first {
+		t.Fatalf("expected the released tab to be reused")
+	}
+	if stats := p.Stats(); stats.Open != 2 || stats.Busy != 2 || stats.Served != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	p.Release(second, false)
+}
+
+func TestTabPoolReplacesTabs(t *testing.T) {
+	p, unhealthy := fakePool(PoolOptions{Size: 1, MaxUses: 2})
+
+	tab := acquire(t, p)
+	p.Release(tab, false)
+	if acquire(t, p) != tab {
+		t.Fatalf("expected the tab to be reused before MaxUses")
+	}
+	p.Release(tab, false)
+	if tab.ctx.Err() == nil {
+		t.Fatalf("expected the tab to be closed after MaxUses")
+	}
+
+	broken := acquire(t, p)
+	if broken == tab {
+		t.Fatalf("expected a fresh tab after recycling")
+	}
+	p.Release(broken, true)
+	if broken.ctx.Err() == nil {
+		t.Fatalf("expected a broken tab to be closed")
+	}
+
+	stale := acquire(t, p)
+	p.Release(stale, false)
+	unhealthy[stale] = true
+	if acquire(t, p) == stale || stale.ctx.Err() == nil {
+		t.Fatalf("expected an unhealthy idle tab to be replaced")
+	}
+	if stats := p.Stats(); stats.Open != 1 || stats.Recycled != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestTabPoolWarmAndClose(t *testing.T) {
+	p, _ := fakePool(PoolOptions{Size: 3})
+	p.Warm(2)
+	if stats := p.Stats(); stats.Idle != 2 {
+		t.Fatalf("expected 2 warm tabs, got %+v", stats)
+	}
+	p.Warm(0)
+	if stats := p.Stats(); stats.Open != 3 {
+		t.Fatalf("expected warming to stop at the pool size, got %+v", stats)
+	}
+
+	leased := acquire(t, p)
+	p.Close()
+	if _, err := p.Acquire(context.Background()); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("expected ErrPoolClosed, got %v", err)
+	}
+	p.Release(leased, false)
+	if stats := p.Stats(); stats.Open != 0 || leased.ctx.Err() == nil {
+		t.Fatalf("expected every tab closed, got %+v", stats)
+	}
+}
+
+func TestTabPoolDropsLostBrowser(t *testing.T) {
+	p, _ := fakePool(PoolOptions{Size: 2})
+	browserCtx, kill := context.WithCancel(context.Background())
+	p.browserCtx, p.browserCancel = browserCtx, kill
+	p.newTab = func() (*Tab, error) {
+		ctx, cancel := context.WithCancel(browserCtx)
+		return &Tab{ctx: ctx, cancel: cancel, browser: browserCtx}, nil
+	}
+
+	tab := acquire(t, p)
+	if tab.BrowserLost() {
+		t.Fatalf("expected a running browser not to be lost")
+	}
+	kill()
+	if !tab.BrowserLost() {
+		t.Fatalf("expected the tab to report its browser lost")
+	}
+
+	p.mu.Lock()
+	dropped := p.dropBrowser(browserCtx)
+	// The watchdog and a lazy restart may both notice; only one counts.
+	again := p.dropBrowser(browserCtx)
+	p.mu.Unlock()
+	if !dropped || again {
+		t.Fatalf("expected the browser dropped exactly once, got %v then %v", dropped, again)
+	}
+
+	p.Release(tab, true)
+	if stats := p.Stats(); stats.Restarts != 1 || stats.Recycled != 1 || p.browserCtx != nil {
+		t.Fatalf("unexpected stats after a crash %+v", stats)
+	}
+}
+
+func TestTabPoolStatsAnswerWhileBrowserStarts(t *testing.T) {
+	p := NewTabPool(PoolOptions{Size: 2})
+	release := make(chan struct{})
+	launches := 0
+	p.launch = func() (context.Context, context.CancelFunc, error) {
+		launches++
+		<-release
+		ctx, cancel := context.WithCancel(context.Background())
+		return ctx, cancel, nil
+	}
+
+	results := make(chan context.Context, 2)
+	for range 2 {
+		go func() {
+			ctx, err := p.browser()
+			if err != nil {
+				t.Errorf("browser: %v", err)
+			}
+			results <- ctx
+		}()
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !p.Stats().Starting {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected stats to report the browser starting")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	first, second := <-results, <-results
+	if first == nil || first != second || launches != 1 {
+		t.Fatalf("expected both callers to share one launch, got %d launches", launches)
+	}
+	if p.Stats().Starting {
+		t.Fatalf("expected starting cleared once the browser is up")
+	}
+	p.Close()
+}
+
+func TestLost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if Lost(ctx) {
+		t.Fatalf("expected a context with no browser yet not to be lost")
+	}
+	cancel()
+	if !Lost(ctx) {
+		t.Fatalf("expected a cancelled context to be lost")
+	}
+}
+
+func TestTabPoolRecycleWaitsForLeasedTabs(t *testing.T) {
+	p, _ := fakePool(PoolOptions{Size: 2})
+	var browsers []context.Context
+	// Open tabs the way openTab does, on the pool's current browser.
+	p.newTab = func() (*Tab, error) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.browserCtx == nil {
+			p.browserCtx, p.browserCancel = context.WithCancel(context.Background())
+			browsers = append(browsers, p.browserCtx)
+		}
+		ctx, cancel := context.WithCancel(p.browserCtx)
+		p.tabsOn[p.browserCtx]++
+		p.browserTabs++
+		return &Tab{ctx: ctx, cancel: cancel, browser: p.browserCtx}, nil
+	}
+
+	leased := acquire(t, p)
+	idle := acquire(t, p)
+	p.Release(idle, false)
+
+	p.Recycle()
+	p.mu.Lock()
+	old := browsers[0]
+	p.mu.Unlock()
+	if old.Err() != nil {
+		t.Fatalf("expected the old browser kept open while a tab is leased")
+	}
+	if idle.ctx.Err() == nil {
+		t.Fatalf("expected idle tabs on the old browser closed")
+	}
+
+	p.Release(leased, false)
+	if leased.ctx.Err() == nil {
+		t.Fatalf("expected a tab on a retired browser closed, not reused")
+	}
+	if old.Err() == nil {
+		t.Fatalf("expected the old browser closed with its last tab")
+	}
+	if stats := p.Stats(); stats.BrowserRecycles != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	p.Close()
+}
+
+func TestTreeMemory(t *testing.T) {
+	ps := `
+    1     0   100
+   10     1  2000
+   11    10   500
+   12    11   250
+   20     1  9999
+ junk
+`
+	if got, want := treeMemory(ps, []int{10}), int64(2750*1024); got != want {
+		t.Fatalf("expected %d bytes for the tree under 10, got %d", want, got)
+	}
+	if got := treeMemory(ps, []int{42}); got != 0 {
+		t.Fatalf("expected nothing for a missing process, got %d", got)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/tmustier/economist-tui/internal/article"
@@ -16,7 +17,17 @@ const articleTTL = time.Hour
 
 const cacheDirName = "cache"
 
+// otherEntryMaxAge is how long PurgeExpired keeps entries other packages
+// store here under a "name-" prefix, such as feeds and edition pages. Their
+// owners decide when they are fresh but fall back to stale copies offline.
+const otherEntryMaxAge = 30 * 24 * time.Hour
+
+// articleCacheVersion is bumped whenever the cached article shape changes;
+// entries written by other versions are treated as misses.
+const articleCacheVersion = 3
+
 type articleEntry struct {
+	Version  int             `json:"version"`
 	CachedAt time.Time       `json:"cached_at"`
 	Article  article.Article `json:"article"`
 }
@@ -36,7 +47,7 @@ func LoadArticle(url string) (*article.Article, bool, error) {
 		return nil, false, err
 	}
 
-	if time.Since(entry.CachedAt) > articleTTL {
+	if entry.Version != articleCacheVersion || time.Since(entry.CachedAt) > articleTTL {
 		_ = os.Remove(path)
 		return nil, false, nil
 	}
@@ -50,6 +61,7 @@ func SaveArticle(art *article.Article) error {
 	}
 
 	entry := articleEntry{
+		Version:  articleCacheVersion,
 		CachedAt: time.Now().UTC(),
 		Article:  *art,
 	}
@@ -87,7 +99,13 @@ func PurgeExpired() error {
 			_ = os.Remove(path)
 			continue
 		}
-		if time.Since(cached.CachedAt) > articleTTL {
+		if strings.Contains(entry.Name(), "-") {
+			if time.Since(cached.CachedAt) > otherEntryMaxAge {
+				_ = os.Remove(path)
+			}
+			continue
+		}
+		if cached.Version != articleCacheVersion || time.Since(cached.CachedAt) > articleTTL {
 			_ = os.Remove(path)
 		}
 	}
@@ -41,6 +41,7 @@ func TestLoadArticleExpires(t *testing.T) {
 	setTempHome(t)
 	url := "https://example.com/expired"
 	entry := articleEntry{
+		Version:  articleCacheVersion,
 		CachedAt: time.Now().Add(-2 * articleTTL),
 		Article:  article.Article{URL: url},
 	}
@@ -70,13 +71,43 @@ func TestLoadArticleExpires(t *testing.T) {
 	}
 }
 
+func TestLoadArticleIgnoresOtherVersions(t *testing.T) {
+	setTempHome(t)
+	url := "https://example.com/legacy"
+	entry := articleEntry{
+		CachedAt: time.Now(),
+		Article:  article.Article{URL: url},
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	path := articleCachePath(url)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	_, ok, err := LoadArticle(url)
+	if err != nil {
+		t.Fatalf("load article: %v", err)
+	}
+	if ok {
+		t.Fatalf("expected cache miss for entry without version")
+	}
+}
+
 func TestPurgeExpired(t *testing.T) {
 	setTempHome(t)
 	freshURL := "https://example.com/fresh"
 	expiredURL := "https://example.com/stale"
 
 	writeEntry := func(url string, cachedAt time.Time) string {
-		entry := articleEntry{CachedAt: cachedAt, Article: article.Article{URL: url}}
+		entry := articleEntry{Version: articleCacheVersion, CachedAt: cachedAt, Article: article.Article{URL: url}}
 		data, err := json.Marshal(entry)
 		if err != nil {
 			t.Fatalf("marshal: %v", err)
@@ -105,3 +136,35 @@ func TestPurgeExpired(t *testing.T) {
 		t.Fatalf("expected fresh cache retained: %v", err)
 	}
 }
+
+func TestPurgeExpiredKeepsOtherEntriesUntilMaxAge(t *testing.T) {
+	setTempHome(t)
+	writeEntry := func(name string, cachedAt time.Time) string {
+		data, err := json.Marshal(map[string]any{"cached_at": cachedAt})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		path := filepath.Join(CacheDir(), name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		return path
+	}
+
+	keptPath := writeEntry("edition-2026-10-10.json", time.Now().Add(-2*articleTTL))
+	expiredPath := writeEntry("edition-2025-10-11.json", time.Now().Add(-2*otherEntryMaxAge))
+
+	if err := PurgeExpired(); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	if _, err := os.Stat(keptPath); err != nil {
+		t.Fatalf("expected an edition page older than an article's TTL kept: %v", err)
+	}
+	if _, err := os.Stat(expiredPath); !os.IsNotExist(err) {
+		t.Fatalf("expected an edition page past the max age removed")
+	}
+}
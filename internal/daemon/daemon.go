@@ -11,11 +11,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/cache"
 	"github.com/tmustier/economist-tui/internal/config"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 	"github.com/tmustier/economist-tui/internal/logging"
@@ -47,32 +48,38 @@ type FetchResponse struct {
 	ErrorType string          `json:"error_type,omitempty"`
 }
 
-type ArticlePayload struct {
-	Overtitle     string `json:"overtitle,omitempty"`
-	Title         string `json:"title"`
-	Subtitle      string `json:"subtitle,omitempty"`
-	DateLine      string `json:"date_line,omitempty"`
-	Content       string `json:"content,omitempty"`
-	URL           string `json:"url"`
-	DebugHTMLPath string `json:"debug_html_path,omitempty"`
-}
+// ArticlePayload is the article as sent over the socket, the same shape
+// `read --json` prints.
+type ArticlePayload = article.Payload
 
 func IsRunning() bool {
-	_, err := ping(context.Background())
+	_, _, err := ping(context.Background())
 	return err == nil
 }
 
+// Status reports whether a daemon is running and how fast it answered. A
+// daemon of another protocol version counts as running, with an error
+// wrapping ErrIncompatible.
 func Status(ctx context.Context) (time.Duration, bool, error) {
-	dur, err := ping(ctx)
+	_, dur, err := ping(ctx)
 	if err == nil {
 		return dur, true, nil
 	}
 	if errors.Is(err, ErrNotRunning) {
 		return 0, false, nil
 	}
+	if errors.Is(err, ErrIncompatible) {
+		return dur, true, err
+	}
 	return 0, false, err
 }
 
+// Inspect returns the running daemon's health and how fast it answered,
+// with the same errors as Status for no daemon or an incompatible one.
+func Inspect(ctx context.Context) (Health, time.Duration, error) {
+	return ping(ctx)
+}
+
 func WaitForReady(ctx context.Context, interval time.Duration) bool {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -113,19 +120,59 @@ func Shutdown(ctx context.Context) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("daemon HTTP %d", resp.StatusCode)
+		return responseError(resp)
 	}
 
 	return nil
 }
 
+// EnsureBackground starts a daemon unless a compatible one is running,
+// replacing one left behind by another version.
 func EnsureBackground() error {
-	if IsRunning() {
+	_, _, err := ping(context.Background())
+	if err == nil {
 		return nil
 	}
+	if errors.Is(err, ErrIncompatible) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := stopStale(ctx); err != nil {
+			return err
+		}
+	}
 	return StartBackground()
 }
 
+// replaceStale stops a daemon found to be incompatible and reports it as
+// not running, so the caller starts a current one and retries.
+func replaceStale(ctx context.Context, reason error, debug bool) error {
+	logging.Debugf(debug, "daemon: %v, stopping it", reason)
+	if err := stopStale(ctx); err != nil {
+		return err
+	}
+	return ErrNotRunning
+}
+
+// stopStale shuts down a daemon of another protocol version and waits for
+// it to let go of the socket. Every version so far accepts POST /shutdown.
+func stopStale(ctx context.Context) error {
+	if err := Shutdown(ctx); err != nil && !errors.Is(err, ErrNotRunning) {
+		return err
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if _, _, err := ping(ctx); errors.Is(err, ErrNotRunning) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 func StartBackground() error {
 	exe, err := os.Executable()
 	if err != nil {
@@ -150,6 +197,13 @@ func StartBackground() error {
 }
 
 func Fetch(ctx context.Context, url string, debug bool) (*article.Article, error) {
+	if _, _, err := ping(ctx); err != nil {
+		if errors.Is(err, ErrIncompatible) {
+			return nil, replaceStale(ctx, err, debug)
+		}
+		return nil, err
+	}
+
 	client, err := newClient()
 	if err != nil {
 		return nil, err
@@ -178,8 +232,11 @@ func Fetch(ctx context.Context, url string, debug bool) (*article.Article, error
 	}
 	defer resp.Body.Close()
 
+	if err := checkProtocol(resp); err != nil {
+		return nil, replaceStale(ctx, err, debug)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("daemon HTTP %d", resp.StatusCode)
+		return nil, responseError(resp)
 	}
 
 	var payload FetchResponse
@@ -201,19 +258,61 @@ func Fetch(ctx context.Context, url string, debug bool) (*article.Article, error
 		return nil, fmt.Errorf("daemon returned empty response")
 	}
 
-	art := &article.Article{
-		Overtitle:     payload.Article.Overtitle,
-		Title:         payload.Article.Title,
-		Subtitle:      payload.Article.Subtitle,
-		DateLine:      payload.Article.DateLine,
-		Content:       payload.Article.Content,
-		URL:           payload.Article.URL,
-		DebugHTMLPath: payload.Article.DebugHTMLPath,
+	return payload.Article.Article(), nil
+}
+
+// ServeOptions configures the daemon. Zero values take the defaults.
+type ServeOptions struct {
+	Tabs    int // browser tabs, and so fetches, at once
+	TabUses int // fetches before a tab is replaced
+
+	// Each time the feed of one of PrefetchSections is refreshed, its
+	// newest PrefetchCount unread articles are fetched into the cache.
+	// With no sections, nothing is prefetched but what /prefetch asks for.
+	PrefetchSections []string
+	PrefetchCount    int
+
+	Limits Limits
+}
+
+// warmURL is loaded into new tabs so the site's scripts are cached before
+// the first article.
+const warmURL = "https://www.economist.com/"
+
+// Prefetch asks the daemon to fetch the articles into the cache ahead of
+// a read. It returns as soon as they are queued.
+func Prefetch(ctx context.Context, urls []string) error {
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(PrefetchRequest{URLs: urls})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://unix/prefetch", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		if isConnRefused(err) {
+			return ErrNotRunning
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkProtocol(resp); err != nil {
+		return err
 	}
-	return art, nil
+	if resp.StatusCode != http.StatusAccepted {
+		return responseError(resp)
+	}
+	return nil
 }
 
-func Serve() error {
+func Serve(opts ServeOptions) error {
 	if err := os.MkdirAll(config.ConfigDir(), 0755); err != nil {
 		return err
 	}
@@ -233,52 +332,112 @@ func Serve() error {
 	}()
 
 	_ = os.Chmod(socketPath, 0600)
-	fmt.Printf("Daemon listening on %s\n", socketPath)
 
+	tabs := browser.NewTabPool(browser.PoolOptions{
+		Size:    opts.Tabs,
+		MaxUses: opts.TabUses,
+		WarmURL: warmURL,
+		Setup:   article.PrepareTab,
+	})
+	defer tabs.Close()
+	go tabs.Warm(1)
+	fmt.Printf("Daemon listening on %s (protocol %d, %d tabs)\n", socketPath, ProtocolVersion, tabs.Stats().Size)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	prefetch := newPrefetcher(func(url string) error {
+		return warmArticle(url, tabs)
+	}, func() bool {
+		stats := tabs.Stats()
+		return stats.Busy < max(1, stats.Size-1)
+	})
+	go prefetch.run(ctx)
+	go prefetch.watchFeeds(ctx, opts.PrefetchSections, opts.PrefetchCount)
+
+	var server *http.Server
+	svc := service{
+		tabs:     tabs,
+		prefetch: prefetch,
+		activity: newActivity(opts.Limits),
+		shutdown: func() {
+			go func() {
+				_ = server.Shutdown(context.Background())
+			}()
+		},
+	}
+	go enforceLimits(ctx, svc, limitsInterval)
+	server = &http.Server{
+		Handler:      newHandler(svc),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 5 * time.Minute,
+	}
+
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// service is what the endpoints work with.
+type service struct {
+	tabs     *browser.TabPool // nil opens a tab per fetch
+	prefetch *prefetcher      // nil turns /prefetch away
+	activity *activity        // nil leaves requests untracked
+	shutdown func()           // called once the reply to /shutdown is on its way
+}
+
+// track counts requests to next towards the idle timeout.
+func (svc service) track(next http.HandlerFunc) http.HandlerFunc {
+	if svc.activity == nil {
+		return next
+	}
+	return svc.activity.track(next)
+}
+
+// newHandler routes the daemon's endpoints. Fetches run in parallel, one
+// per pooled tab. Unknown paths and wrong methods get an ErrorResponse
+// rather than a bare status.
+func newHandler(svc service) http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("ok"))
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		writeJSON(w, http.StatusOK, currentHealth(svc))
 	})
 
-	var server *http.Server
 	mux.HandleFunc("/shutdown", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+		if !allowMethod(w, r, http.MethodPost) {
 			return
 		}
 		w.WriteHeader(http.StatusOK)
-		if server != nil {
-			go func() {
-				_ = server.Shutdown(context.Background())
-			}()
-		}
+		svc.shutdown()
 	})
 
-	var fetchMu sync.Mutex
-	mux.HandleFunc("/fetch", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+	mux.HandleFunc("/fetch", svc.track(func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
 			return
 		}
 		var req FetchRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid fetch request: %v", err)
 			return
 		}
-		fetchMu.Lock()
-		defer fetchMu.Unlock()
 
 		start := time.Now()
 		logging.Debugf(req.Debug, "daemon: fetch start url=%s", req.URL)
+		if svc.prefetch != nil && svc.prefetch.waitFor(r.Context(), req.URL) && !req.Debug {
+			if art, ok, _ := cache.LoadArticle(req.URL); ok {
+				writeJSON(w, http.StatusOK, FetchResponse{Article: article.NewPayload(art)})
+				return
+			}
+		}
 		cfg, cfgErr := config.Load()
 		if cfgErr != nil {
-			resp := FetchResponse{Error: cfgErr.Error()}
-			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(resp)
+			writeJSON(w, http.StatusOK, FetchResponse{Error: cfgErr.Error()})
 			return
 		}
-		art, err := article.FetchWithCookies(req.URL, article.FetchOptions{Debug: req.Debug}, cfg.Cookies)
+		art, err := article.FetchWithCookies(req.URL, article.FetchOptions{Debug: req.Debug, Tabs: svc.tabs, Context: r.Context()}, cfg.Cookies)
 		logging.Debugf(req.Debug, "daemon: fetch done in %s err=%v", time.Since(start), err)
 
 		resp := FetchResponse{}
@@ -290,50 +449,70 @@ func Serve() error {
 				resp.ErrorType = "user"
 			}
 		} else {
-			resp.Article = &ArticlePayload{
-				Overtitle:     art.Overtitle,
-				Title:         art.Title,
-				Subtitle:      art.Subtitle,
-				DateLine:      art.DateLine,
-				Content:       art.Content,
-				URL:           art.URL,
-				DebugHTMLPath: art.DebugHTMLPath,
-			}
+			resp.Article = article.NewPayload(art)
 		}
+		writeJSON(w, http.StatusOK, resp)
+	}))
 
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	})
+	mux.HandleFunc("/prefetch", svc.track(func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		if svc.prefetch == nil {
+			writeError(w, http.StatusServiceUnavailable, "unavailable", "prefetch is not running")
+			return
+		}
+		var req PrefetchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid prefetch request: %v", err)
+			return
+		}
+		queued := svc.prefetch.enqueue(req.URLs, true)
+		writeJSON(w, http.StatusAccepted, PrefetchResponse{Queued: queued})
+	}))
 
-	server = &http.Server{
-		Handler:      mux,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 5 * time.Minute,
-	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "unknown endpoint %s", r.URL.Path)
+	})
 
-	return server.Serve(listener)
+	return withProtocol(mux)
 }
 
-func ping(ctx context.Context) (time.Duration, error) {
+// ping asks the daemon for its health. It returns ErrNotRunning when no
+// daemon answers and ErrIncompatible when one of another version does.
+func ping(ctx context.Context) (Health, time.Duration, error) {
 	client, err := newClient()
 	if err != nil {
-		return 0, err
+		return Health{}, 0, err
 	}
 
 	start := time.Now()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://unix/health", nil)
 	if err != nil {
-		return 0, err
+		return Health{}, 0, err
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return 0, err
+		if isConnRefused(err) {
+			return Health{}, 0, ErrNotRunning
+		}
+		return Health{}, 0, err
 	}
 	defer resp.Body.Close()
+	latency := time.Since(start)
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("daemon HTTP %d", resp.StatusCode)
+		return Health{}, 0, responseError(resp)
+	}
+
+	// Daemons before the protocol version answered a plain "ok".
+	var health Health
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return Health{}, latency, fmt.Errorf("%w (protocol none, want %d)", ErrIncompatible, ProtocolVersion)
+	}
+	if err := health.compatible(); err != nil {
+		return health, latency, err
 	}
-	return time.Since(start), nil
+	return health, latency, nil
 }
 
 func newClient() (*http.Client, error) {
@@ -3,17 +3,33 @@ package daemon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/tmustier/economist-tui/internal/article"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 )
 
+// withTestDaemon serves handler behind a current /health, as a daemon of
+// this version would.
 func withTestDaemon(t *testing.T, handler http.Handler) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, currentHealth(service{}))
+	})
+	mux.Handle("/", handler)
+	serveTestSocket(t, withProtocol(mux))
+}
+
+func serveTestSocket(t *testing.T, handler http.Handler) {
 	t.Helper()
 	home, err := os.MkdirTemp("/tmp", "economist-daemon-")
 	if err != nil {
@@ -54,7 +70,9 @@ func TestFetchMapsPayload(t *testing.T) {
 			Title:     "Title",
 			Subtitle:  "Subtitle",
 			DateLine:  "Jan 1st 2024",
-			Content:   "Body",
+			Byline:    "Our correspondent",
+			WordCount: 1,
+			Blocks:    []article.Block{{Kind: article.BlockParagraph, Text: "Body"}},
 			URL:       "https://example.com/test",
 		}}
 		_ = json.NewEncoder(w).Encode(resp)
@@ -73,6 +91,9 @@ func TestFetchMapsPayload(t *testing.T) {
 	if art.URL != "https://example.com/test" {
 		t.Fatalf("expected url, got %q", art.URL)
 	}
+	if art.Byline != "Our correspondent" || art.WordCount != 1 {
+		t.Fatalf("expected metadata fields, got %#v", art)
+	}
 }
 
 func TestFetchMapsErrors(t *testing.T) {
@@ -93,3 +114,72 @@ func TestFetchMapsErrors(t *testing.T) {
 		t.Fatalf("expected paywall error, got %v", err)
 	}
 }
+
+func TestFetchStopsStaleDaemon(t *testing.T) {
+	// A daemon from before the protocol version: plain "ok" health, no
+	// protocol header, and a /shutdown that takes it down.
+	stopped := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/shutdown", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		close(stopped)
+		_ = os.Remove(SocketPath())
+	})
+	mux.HandleFunc("/fetch", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("fetch should not reach a stale daemon")
+	})
+	serveTestSocket(t, mux)
+
+	_, running, err := Status(context.Background())
+	if !running || !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("expected a running incompatible daemon, got %v, %v", running, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if _, err := Fetch(ctx, "https://example.com/test", false); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning after stopping the stale daemon, got %v", err)
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Fatalf("expected the stale daemon to be shut down")
+	}
+}
+
+func TestHandlerHealthAndErrors(t *testing.T) {
+	handler := newHandler(service{shutdown: func() {}})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	var health Health
+	if err := json.NewDecoder(rec.Body).Decode(&health); err != nil {
+		t.Fatalf("decode health: %v", err)
+	}
+	if err := health.compatible(); err != nil || rec.Header().Get(protocolHeader) != "1" {
+		t.Fatalf("expected a compatible health reply, got %#v (%v)", health, err)
+	}
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+		errorType    string
+	}{
+		{http.MethodGet, "/prefetch-everything", http.StatusNotFound, "not_found"},
+		{http.MethodGet, "/fetch", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{http.MethodPost, "/fetch", http.StatusBadRequest, "bad_request"},
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader("{")))
+		var body ErrorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("%s %s: decode error body: %v", tc.method, tc.path, err)
+		}
+		if rec.Code != tc.status || body.ErrorType != tc.errorType || body.Error == "" {
+			t.Fatalf("%s %s: got %d %#v", tc.method, tc.path, rec.Code, body)
+		}
+	}
+}
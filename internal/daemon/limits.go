@@ -0,0 +1,152 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/browser"
+)
+
+const (
+	DefaultIdleTimeout    = 30 * time.Minute
+	DefaultMaxMemoryMB    = 1024
+	DefaultBrowserMaxTabs = 200
+
+	// limitsInterval is how often the idle timeout and resource limits are
+	// checked, and memory sampled for /health.
+	limitsInterval = 30 * time.Second
+)
+
+// Limits bound how long an unused daemon lingers and how much Chrome may
+// hold. Zero turns a limit off.
+type Limits struct {
+	IdleTimeout    time.Duration // exit after this long with no requests
+	MaxMemoryMB    int           // recycle Chrome past this resident size
+	BrowserMaxTabs int           // recycle Chrome after opening this many tabs
+}
+
+// Usage is the daemon's uptime, activity and memory, as reported on /health.
+type Usage struct {
+	StartedAt     time.Time `json:"started_at"`
+	LastRequestAt time.Time `json:"last_request_at,omitzero"`
+	// IdleTimeout is in seconds; zero means the daemon never exits idle.
+	IdleTimeout int `json:"idle_timeout,omitempty"`
+	// Memory is the daemon's resident size with Chrome, BrowserMemory
+	// Chrome's share, both in bytes as last sampled.
+	Memory        int64 `json:"memory,omitempty"`
+	BrowserMemory int64 `json:"browser_memory,omitempty"`
+}
+
+// activity tracks client requests for the idle timeout, and the latest
+// memory sample for /health.
+type activity struct {
+	limits Limits
+
+	mu       sync.Mutex
+	usage    Usage
+	inFlight int
+}
+
+func newActivity(limits Limits) *activity {
+	return &activity{
+		limits: limits,
+		usage: Usage{
+			StartedAt:   time.Now(),
+			IdleTimeout: int(limits.IdleTimeout / time.Second),
+		},
+	}
+}
+
+// track counts requests to next as activity; health checks are left out
+// so that `serve --status` and pings do not keep the daemon alive.
+func (a *activity) track(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		a.inFlight++
+		a.usage.LastRequestAt = time.Now()
+		a.mu.Unlock()
+		defer func() {
+			a.mu.Lock()
+			a.inFlight--
+			a.usage.LastRequestAt = time.Now()
+			a.mu.Unlock()
+		}()
+		next(w, r)
+	}
+}
+
+// idle reports how long the daemon has gone without a request; a request
+// still being served keeps it at zero.
+func (a *activity) idle(now time.Time) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.inFlight > 0 {
+		return 0
+	}
+	last := a.usage.LastRequestAt
+	if last.IsZero() {
+		last = a.usage.StartedAt
+	}
+	return now.Sub(last)
+}
+
+func (a *activity) Usage() Usage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.usage
+}
+
+func (a *activity) setMemory(total, chrome int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.usage.Memory = total
+	a.usage.BrowserMemory = chrome
+}
+
+// enforceLimits checks the limits every interval until ctx is done: it
+// shuts the daemon down once idle for IdleTimeout and recycles Chrome when
+// it outgrows MaxMemoryMB or has opened BrowserMaxTabs tabs.
+func enforceLimits(ctx context.Context, svc service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	limits := svc.activity.limits
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if idle := svc.activity.idle(time.Now()); limits.IdleTimeout > 0 && idle >= limits.IdleTimeout {
+			fmt.Printf("No requests for %s, shutting down\n", idle.Round(time.Second))
+			svc.shutdown()
+			return
+		}
+		if svc.tabs == nil {
+			continue
+		}
+
+		total, _ := browser.ProcessMemory(os.Getpid())
+		chrome, err := svc.tabs.MemoryUsage()
+		if err != nil {
+			continue
+		}
+		svc.activity.setMemory(total, chrome)
+		if svc.tabs.Stats().Retiring > 0 {
+			// A recycle is still waiting on leased tabs.
+			continue
+		}
+		switch {
+		case limits.MaxMemoryMB > 0 && chrome > int64(limits.MaxMemoryMB)<<20:
+			fmt.Printf("Chrome is using %d MB (limit %d MB), recycling it\n", chrome>>20, limits.MaxMemoryMB)
+			svc.tabs.Recycle()
+		case limits.BrowserMaxTabs > 0 && svc.tabs.Stats().BrowserTabs >= limits.BrowserMaxTabs:
+			fmt.Printf("Chrome has opened %d tabs, recycling it\n", limits.BrowserMaxTabs)
+			svc.tabs.Recycle()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityTracksRequests(t *testing.T) {
+	act := newActivity(Limits{IdleTimeout: time.Hour})
+	start := act.Usage().StartedAt
+	if idle := act.idle(start.Add(time.Minute)); idle != time.Minute {
+		t.Fatalf("expected idle since start, got %s", idle)
+	}
+
+	release := make(chan struct{})
+	served := make(chan struct{})
+	handler := act.track(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	go func() {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/fetch", nil))
+		close(served)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for act.Usage().LastRequestAt.IsZero() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if idle := act.idle(time.Now().Add(time.Hour)); idle != 0 {
+		t.Fatalf("expected a request in flight to keep the daemon busy, got %s idle", idle)
+	}
+	close(release)
+	<-served
+
+	usage := act.Usage()
+	if usage.LastRequestAt.Before(start) || usage.IdleTimeout != 3600 {
+		t.Fatalf("unexpected usage %+v", usage)
+	}
+}
+
+func TestEnforceLimitsShutsDownWhenIdle(t *testing.T) {
+	shutdown := make(chan struct{})
+	svc := service{
+		activity: newActivity(Limits{IdleTimeout: 20 * time.Millisecond}),
+		shutdown: func() { close(shutdown) },
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go enforceLimits(ctx, svc, 5*time.Millisecond)
+
+	select {
+	case <-shutdown:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected an idle daemon to shut down")
+	}
+}
+
+func TestHealthReportsUsage(t *testing.T) {
+	svc := service{activity: newActivity(Limits{}), shutdown: func() {}}
+	handler := newHandler(svc)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if !strings.Contains(rec.Body.String(), `"started_at"`) || strings.Contains(rec.Body.String(), `"last_request_at"`) {
+		t.Fatalf("expected usage without a last request, got %s", rec.Body.String())
+	}
+	if !svc.activity.Usage().LastRequestAt.IsZero() {
+		t.Fatalf("expected health checks not to count as requests")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prefetch", strings.NewReader(`{}`)))
+	if svc.activity.Usage().LastRequestAt.IsZero() {
+		t.Fatalf("expected /prefetch to count as a request")
+	}
+}
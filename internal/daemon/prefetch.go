@@ -0,0 +1,289 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/config"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/readstate"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/search"
+)
+
+const (
+	DefaultPrefetchCount = 5
+
+	// feedPollInterval is how often the RSS cache is checked for feeds
+	// refreshed since the last look.
+	feedPollInterval = 30 * time.Second
+
+	// prefetchQueueSize bounds each queue; URLs beyond it are dropped, as a
+	// later refresh or cursor move will ask again.
+	prefetchQueueSize = 64
+	// prefetchWait is how long background work waits for the tab pool to
+	// have room to spare.
+	prefetchWait = 500 * time.Millisecond
+)
+
+// PrefetchRequest is the body of POST /prefetch.
+type PrefetchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+// PrefetchResponse reports how many of the URLs were queued; the rest
+// were cached or already queued.
+type PrefetchResponse struct {
+	Queued int `json:"queued"`
+}
+
+// PrefetchStats counts the prefetcher's work since the daemon started.
+type PrefetchStats struct {
+	Queued  int `json:"queued"`
+	Fetched int `json:"fetched"`
+	Failed  int `json:"failed"`
+}
+
+// prefetcher fetches articles into the cache ahead of a read. Requests
+// from /prefetch are urgent and start at once; articles from refreshed
+// feeds are background work that only runs while the tab pool has a tab
+// to spare, so it never holds up a read.
+type prefetcher struct {
+	warm func(url string) error
+	// spare reports whether background work may take a tab now.
+	spare func() bool
+	// cachedFeed reads a section's feed from the RSS cache; swapped out in
+	// tests.
+	cachedFeed func(section string) (*rss.RSS, time.Time, bool)
+
+	urgent     chan string
+	background chan string
+
+	mu      sync.Mutex
+	pending map[string]bool
+	// inflight holds a channel per URL being fetched, closed when done.
+	inflight map[string]chan struct{}
+	stats    PrefetchStats
+}
+
+func newPrefetcher(warm func(url string) error, spare func() bool) *prefetcher {
+	return &prefetcher{
+		warm:       warm,
+		spare:      spare,
+		urgent:     make(chan string, prefetchQueueSize),
+		background: make(chan string, prefetchQueueSize),
+		pending:    make(map[string]bool),
+		inflight:   make(map[string]chan struct{}),
+		cachedFeed: rss.CachedSection,
+	}
+}
+
+// run works through the queues until ctx is done: one worker for urgent
+// requests only, so they never wait behind background work, and one that
+// takes background work when there is nothing urgent.
+func (p *prefetcher) run(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case url := <-p.urgent:
+				p.fetch(url)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case url := <-p.urgent:
+			p.fetch(url)
+			continue
+		default:
+		}
+		select {
+		case url := <-p.urgent:
+			p.fetch(url)
+		case url := <-p.background:
+			if !p.waitForSpare(ctx) {
+				return
+			}
+			p.fetch(url)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *prefetcher) waitForSpare(ctx context.Context) bool {
+	for !p.spare() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(prefetchWait):
+		}
+	}
+	return true
+}
+
+// enqueue queues the URLs that are neither cached nor already queued and
+// returns how many it took.
+func (p *prefetcher) enqueue(urls []string, urgent bool) int {
+	queue := p.background
+	if urgent {
+		queue = p.urgent
+	}
+	queued := 0
+	for _, url := range urls {
+		url = strings.TrimSpace(url)
+		if url == "" || isCached(url) {
+			continue
+		}
+		p.mu.Lock()
+		if p.pending[url] {
+			p.mu.Unlock()
+			continue
+		}
+		select {
+		case queue <- url:
+			p.pending[url] = true
+			p.stats.Queued++
+			queued++
+		default:
+		}
+		p.mu.Unlock()
+	}
+	return queued
+}
+
+func (p *prefetcher) fetch(url string) {
+	done := make(chan struct{})
+	p.mu.Lock()
+	p.inflight[url] = done
+	p.mu.Unlock()
+
+	err := p.warm(url)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pending, url)
+	delete(p.inflight, url)
+	close(done)
+	if err != nil {
+		p.stats.Failed++
+	} else {
+		p.stats.Fetched++
+	}
+}
+
+// waitFor blocks while a prefetch of the URL is in flight, so a read of a
+// headline the cursor just rested on shares that fetch rather than loading
+// the page in a second tab. It reports whether it waited for one to finish.
+func (p *prefetcher) waitFor(ctx context.Context, url string) bool {
+	p.mu.Lock()
+	done, ok := p.inflight[strings.TrimSpace(url)]
+	p.mu.Unlock()
+	if !ok {
+		return false
+	}
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *prefetcher) Stats() PrefetchStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// watchFeeds queues the newest unread articles of the sections as
+// background work each time their feed is refreshed, by browse, headlines
+// or any other command reading the section. The daemon never fetches the
+// feeds itself, so it only prefetches what the user is following.
+func (p *prefetcher) watchFeeds(ctx context.Context, sections []string, count int) {
+	if len(sections) == 0 || count < 1 {
+		return
+	}
+	seen := make(map[string]time.Time)
+	for {
+		if config.IsLoggedIn() {
+			p.queueRefreshed(sections, count, seen)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(feedPollInterval):
+		}
+	}
+}
+
+// queueRefreshed queues articles from the sections whose cached feed is
+// newer than the one last seen, and returns how many it queued.
+func (p *prefetcher) queueRefreshed(sections []string, count int, seen map[string]time.Time) int {
+	state, _ := readstate.Load()
+	queued := 0
+	for _, section := range sections {
+		feed, cachedAt, ok := p.cachedFeed(section)
+		if !ok || !cachedAt.After(seen[section]) {
+			continue
+		}
+		seen[section] = cachedAt
+		queued += p.enqueue(unreadURLs(feed, state, count), false)
+	}
+	return queued
+}
+
+// unreadURLs returns up to count unread article URLs from the top of the
+// feed.
+func unreadURLs(feed *rss.RSS, state *readstate.State, count int) []string {
+	var urls []string
+	for _, item := range feed.Channel.Items {
+		if len(urls) == count {
+			break
+		}
+		if state.IsRead(item.Key()) {
+			continue
+		}
+		urls = append(urls, strings.TrimSpace(item.Link))
+	}
+	return urls
+}
+
+func isCached(url string) bool {
+	_, ok, _ := cache.LoadArticle(url)
+	return ok
+}
+
+// warmArticle fetches an article into the cache the way a read would:
+// over HTTP when the cookies get the full text, else in a pooled tab.
+func warmArticle(url string, tabs *browser.TabPool) error {
+	if isCached(url) {
+		return nil
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	art, err := article.FetchHTTPWithCookies(url, article.FetchOptions{}, cfg.Cookies)
+	if err != nil {
+		art, err = article.FetchWithCookies(url, article.FetchOptions{Tabs: tabs}, cfg.Cookies)
+		if err != nil {
+			return err
+		}
+	}
+	if !art.HasBody() {
+		return appErrors.NewUserError("no article content found at %s", url)
+	}
+	if err := search.IndexArticle(art); err != nil {
+		fmt.Printf("prefetch: index %s: %v\n", url, err)
+	}
+	return cache.SaveArticle(art)
+}
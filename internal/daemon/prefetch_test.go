@@ -0,0 +1,165 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+func TestPrefetcherPutsUrgentWorkFirst(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var mu sync.Mutex
+	var fetched []string
+	done := make(chan struct{}, 8)
+	var spare bool
+	p := newPrefetcher(func(url string) error {
+		mu.Lock()
+		fetched = append(fetched, url)
+		mu.Unlock()
+		done <- struct{}{}
+		return nil
+	}, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return spare
+	})
+
+	if n := p.enqueue([]string{"https://example.com/bg", "https://example.com/bg"}, false); n != 1 {
+		t.Fatalf("expected a repeated URL queued once, got %d", n)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.run(ctx)
+
+	p.enqueue([]string{"https://example.com/now"}, true)
+	wait(t, done)
+	mu.Lock()
+	if len(fetched) != 1 || fetched[0] != "https://example.com/now" {
+		t.Fatalf("expected only the urgent URL while no tab is spare, got %v", fetched)
+	}
+	spare = true
+	mu.Unlock()
+
+	wait(t, done)
+	if stats := p.Stats(); stats.Queued != 2 || stats.Fetched != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func wait(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for a prefetch")
+	}
+}
+
+func TestPrefetchEndpointQueues(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	p := newPrefetcher(func(string) error { return nil }, func() bool { return true })
+	handler := newHandler(service{prefetch: p, shutdown: func() {}})
+
+	rec := httptest.NewRecorder()
+	body := `{"urls":["https://example.com/a","https://example.com/a","https://example.com/b"]}`
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prefetch", strings.NewReader(body)))
+	var resp PrefetchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusAccepted || resp.Queued != 2 {
+		t.Fatalf("expected 2 queued, got %d %+v", rec.Code, resp)
+	}
+}
+
+func TestFetchWaitsForPrefetchOfSameURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	url := "https://www.economist.com/finance/bonds"
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	warms := 0
+	p := newPrefetcher(func(url string) error {
+		warms++
+		close(started)
+		<-release
+		return cache.SaveArticle(&article.Article{
+			URL:    url,
+			Title:  "Bonds",
+			Blocks: article.ParagraphBlocks("Yields rose."),
+		})
+	}, func() bool { return true })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.run(ctx)
+	p.enqueue([]string{url}, true)
+	wait(t, started)
+
+	handler := newHandler(service{prefetch: p, shutdown: func() {}})
+	rec := httptest.NewRecorder()
+	served := make(chan struct{})
+	go func() {
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/fetch", strings.NewReader(`{"url":"`+url+`"}`)))
+		close(served)
+	}()
+	select {
+	case <-served:
+		t.Fatalf("expected /fetch to wait for the prefetch in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	wait(t, served)
+
+	var resp FetchResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Error != "" || resp.Article == nil || resp.Article.Title != "Bonds" {
+		t.Fatalf("expected the prefetched article, got %+v", resp)
+	}
+	if warms != 1 {
+		t.Fatalf("expected one fetch of the page, got %d", warms)
+	}
+}
+
+func TestPrefetcherQueuesRefreshedFeeds(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	p := newPrefetcher(func(string) error { return nil }, func() bool { return true })
+	refreshed := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	p.cachedFeed = func(section string) (*rss.RSS, time.Time, bool) {
+		if section != "leaders" {
+			return nil, time.Time{}, false
+		}
+		feed := &rss.RSS{}
+		for _, slug := range []string{"a", "b", "c"} {
+			feed.Channel.Items = append(feed.Channel.Items, rss.Item{Link: "https://www.economist.com/leaders/" + slug})
+		}
+		return feed, refreshed, true
+	}
+
+	seen := make(map[string]time.Time)
+	sections := []string{"leaders", "finance"}
+	if n := p.queueRefreshed(sections, 2, seen); n != 2 {
+		t.Fatalf("expected the top 2 articles queued after a refresh, got %d", n)
+	}
+	if n := p.queueRefreshed(sections, 2, seen); n != 0 {
+		t.Fatalf("expected nothing queued without a new refresh, got %d", n)
+	}
+	refreshed = refreshed.Add(time.Minute)
+	p.mu.Lock()
+	p.pending = make(map[string]bool)
+	p.mu.Unlock()
+	if n := p.queueRefreshed(sections, 2, seen); n != 2 {
+		t.Fatalf("expected articles queued again after the next refresh, got %d", n)
+	}
+}
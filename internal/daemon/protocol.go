@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/browser"
+)
+
+// ProtocolVersion is the version of the socket API. It goes up whenever a
+// request or response changes shape; a binary only talks to a daemon of
+// the same version and replaces any other.
+const ProtocolVersion = 1
+
+// protocolHeader carries ProtocolVersion on every response, so a client
+// notices a stale daemon on any call. Daemons that predate it send none.
+const protocolHeader = "X-Economist-Protocol"
+
+// Capabilities lists the endpoints this daemon serves.
+var Capabilities = []string{"health", "fetch", "parallel-fetch", "prefetch", "shutdown"}
+
+// requiredCapabilities are the endpoints the client relies on.
+var requiredCapabilities = []string{"fetch", "shutdown"}
+
+// ErrIncompatible means a daemon is running but speaks another protocol,
+// typically one left over from before an upgrade.
+var ErrIncompatible = errors.New("economist serve is running an incompatible version")
+
+// Health is the reply to /health.
+type Health struct {
+	Status       string   `json:"status"`
+	Protocol     int      `json:"protocol"`
+	Payload      int      `json:"payload"`
+	Capabilities []string `json:"capabilities"`
+	PID          int      `json:"pid"`
+	// Tabs describes the browser tab pool fetches run on.
+	Tabs *browser.PoolStats `json:"tabs,omitempty"`
+	// Prefetch counts articles fetched ahead of a read.
+	Prefetch *PrefetchStats `json:"prefetch,omitempty"`
+	// Usage has the daemon's uptime, last request and memory.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// ErrorResponse is the body of every non-200 reply.
+type ErrorResponse struct {
+	Error     string `json:"error"`
+	ErrorType string `json:"error_type"`
+}
+
+func currentHealth(svc service) Health {
+	health := Health{
+		Status:       "ok",
+		Protocol:     ProtocolVersion,
+		Payload:      article.PayloadVersion,
+		Capabilities: Capabilities,
+		PID:          os.Getpid(),
+	}
+	if svc.tabs != nil {
+		stats := svc.tabs.Stats()
+		health.Tabs = &stats
+	}
+	if svc.prefetch != nil {
+		stats := svc.prefetch.Stats()
+		health.Prefetch = &stats
+	}
+	if svc.activity != nil {
+		usage := svc.activity.Usage()
+		health.Usage = &usage
+	}
+	return health
+}
+
+// compatible reports why a daemon's health rules it out, or nil.
+func (h Health) compatible() error {
+	if h.Protocol != ProtocolVersion {
+		return fmt.Errorf("%w (protocol %d, want %d)", ErrIncompatible, h.Protocol, ProtocolVersion)
+	}
+	for _, capability := range requiredCapabilities {
+		if !slices.Contains(h.Capabilities, capability) {
+			return fmt.Errorf("%w (no %s)", ErrIncompatible, capability)
+		}
+	}
+	return nil
+}
+
+func withProtocol(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(protocolHeader, strconv.Itoa(ProtocolVersion))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkProtocol returns ErrIncompatible when the reply comes from a daemon
+// of another protocol version.
+func checkProtocol(resp *http.Response) error {
+	got := resp.Header.Get(protocolHeader)
+	if got == strconv.Itoa(ProtocolVersion) {
+		return nil
+	}
+	if got == "" {
+		got = "none"
+	}
+	return fmt.Errorf("%w (protocol %s, want %d)", ErrIncompatible, got, ProtocolVersion)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, errorType, format string, args ...any) {
+	writeJSON(w, status, ErrorResponse{Error: fmt.Sprintf(format, args...), ErrorType: errorType})
+}
+
+// allowMethod replies 405 unless the request uses method.
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "%s %s not allowed (use %s)", r.Method, r.URL.Path, method)
+	return false
+}
+
+// responseError turns a non-200 reply into an error, using the daemon's
+// message when it sent one.
+func responseError(resp *http.Response) error {
+	var body ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error != "" {
+		return fmt.Errorf("daemon HTTP %d: %s", resp.StatusCode, body.Error)
+	}
+	return fmt.Errorf("daemon HTTP %d", resp.StatusCode)
+}
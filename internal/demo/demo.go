@@ -160,7 +160,7 @@ func (s *Source) addFixtures() error {
 			Title:     spec.Title,
 			Subtitle:  spec.Subtitle,
 			DateLine:  formatDateLine(published),
-			Content:   content,
+			Blocks:    article.ParagraphBlocks(content),
 			URL:       url,
 		}
 	}
@@ -40,17 +40,18 @@ func TestDemoSource(t *testing.T) {
 	if err != nil {
 		t.Fatalf("article: %v", err)
 	}
-	if art.Title == "" || art.Content == "" {
+	content := art.BodyText()
+	if art.Title == "" || content == "" {
 		t.Fatalf("expected article content")
 	}
-	if !strings.Contains(strings.ToLower(art.Content), "destroyers") {
-		snippet := art.Content
+	if !strings.Contains(strings.ToLower(content), "destroyers") {
+		snippet := content
 		if len(snippet) > 80 {
 			snippet = snippet[:80]
 		}
 		t.Fatalf("expected fixture content, got %q", snippet)
 	}
-	if !strings.HasSuffix(strings.TrimSpace(art.Content), "■") {
+	if !strings.HasSuffix(strings.TrimSpace(content), "■") {
 		t.Fatalf("expected trailing marker")
 	}
 }
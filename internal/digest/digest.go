@@ -0,0 +1,186 @@
+package digest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/rss"
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+// DefaultSections is the digest's section order when none is given,
+// roughly the order of the print edition.
+var DefaultSections = []string{
+	"leaders", "briefing", "us", "china", "americas", "middle-east", "europe",
+	"britain", "asia", "business", "finance", "science", "culture",
+}
+
+// SectionSpec names a section and caps its items. Limit 0 means the
+// digest-wide cap.
+type SectionSpec struct {
+	Name  string
+	Limit int
+}
+
+// ParseSectionSpec parses "section" or "section:N".
+func ParseSectionSpec(spec string) (SectionSpec, error) {
+	name, limit, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if name == "" {
+		return SectionSpec{}, appErrors.NewUserError("empty section in %q", spec)
+	}
+	if !ok {
+		return SectionSpec{Name: name}, nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 1 {
+		return SectionSpec{}, appErrors.NewUserError("invalid item cap in %q - use section:N", spec)
+	}
+	return SectionSpec{Name: name, Limit: n}, nil
+}
+
+// Options selects what goes into a digest.
+type Options struct {
+	Title    string // defaults to one naming the day
+	Sections []SectionSpec
+	Since    time.Time
+	Limit    int  // items per section unless the section sets its own; 0 = all
+	Full     bool // fetch each article's body
+}
+
+// Fetcher loads feeds and articles. A nil Section uses rss.FetchSection;
+// a nil Article leaves bodies out.
+type Fetcher struct {
+	Section func(section string) (*rss.RSS, error)
+	Article func(url string) (*article.Article, error)
+}
+
+// Entry is one headline, with its article when bodies were fetched.
+type Entry struct {
+	Item       rss.Item
+	Article    *article.Article
+	ArticleErr error
+}
+
+// Section is a section's new headlines, newest first. Err is set when the
+// feed could not be loaded.
+type Section struct {
+	Name    string
+	Title   string
+	Entries []Entry
+	Err     error
+}
+
+// Digest is a briefing of the headlines published since a point in time.
+type Digest struct {
+	Title     string
+	Since     time.Time
+	Generated time.Time
+	Sections  []Section
+}
+
+// Count returns the number of headlines in the digest.
+func (d *Digest) Count() int {
+	count := 0
+	for _, section := range d.Sections {
+		count += len(section.Entries)
+	}
+	return count
+}
+
+// Build assembles the digest. A headline carried by several feeds is
+// listed once, under the first section to carry it. Sections that fail to
+// load are kept with their error so the rest of the digest still renders.
+func Build(opts Options, fetcher Fetcher) (*Digest, error) {
+	if fetcher.Section == nil {
+		fetcher.Section = rss.FetchSection
+	}
+	specs := opts.Sections
+	if len(specs) == 0 {
+		for _, name := range DefaultSections {
+			specs = append(specs, SectionSpec{Name: name})
+		}
+	}
+
+	d := &Digest{Title: opts.Title, Since: opts.Since, Generated: time.Now()}
+	if d.Title == "" {
+		d.Title = "The Economist · " + d.Generated.Format("Monday 2 January 2006")
+	}
+	seen := make(map[string]bool)
+	failed := 0
+	for _, spec := range specs {
+		section := Section{Name: spec.Name, Title: sectionTitle(spec.Name, "")}
+		feed, err := fetcher.Section(spec.Name)
+		if err != nil {
+			section.Err = err
+			failed++
+			d.Sections = append(d.Sections, section)
+			continue
+		}
+		section.Title = sectionTitle(spec.Name, feed.Channel.Title)
+
+		limit := spec.Limit
+		if limit == 0 {
+			limit = opts.Limit
+		}
+		for _, item := range newItems(feed.Channel.Items, opts.Since) {
+			if limit > 0 && len(section.Entries) >= limit {
+				break
+			}
+			if seen[item.Key()] {
+				continue
+			}
+			seen[item.Key()] = true
+			entry := Entry{Item: item}
+			if opts.Full && fetcher.Article != nil {
+				entry.Article, entry.ArticleErr = fetcher.Article(strings.TrimSpace(item.Link))
+			}
+			section.Entries = append(section.Entries, entry)
+		}
+		d.Sections = append(d.Sections, section)
+	}
+	if failed == len(specs) {
+		return nil, d.Sections[0].Err
+	}
+	return d, nil
+}
+
+// newItems returns the items published after since, newest first. Items
+// without a readable date are left out.
+func newItems(items []rss.Item, since time.Time) []rss.Item {
+	type dated struct {
+		item rss.Item
+		at   time.Time
+	}
+	var kept []dated
+	for _, item := range items {
+		at, ok := item.Published()
+		if !ok || !at.After(since) {
+			continue
+		}
+		kept = append(kept, dated{item, at})
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].at.After(kept[j].at)
+	})
+	out := make([]rss.Item, len(kept))
+	for i, k := range kept {
+		out[i] = k.item
+	}
+	return out
+}
+
+// sectionTitle prefers the feed's title, without the publication name.
+func sectionTitle(name, feedTitle string) string {
+	title := strings.TrimSpace(feedTitle)
+	for _, prefix := range []string{"The Economist:", "The Economist -"} {
+		title = strings.TrimSpace(strings.TrimPrefix(title, prefix))
+	}
+	if title == "" {
+		title = strings.Trim(ui.SectionLabel(name), "[]")
+	}
+	return title
+}
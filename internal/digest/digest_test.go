@@ -0,0 +1,116 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+func feed(title string, items ...rss.Item) *rss.RSS {
+	return &rss.RSS{Channel: rss.Channel{Title: title, Items: items}}
+}
+
+func item(title, link string, at time.Time) rss.Item {
+	return rss.Item{Title: title, Link: link, Description: title + " summary", PubDate: at.Format(time.RFC1123Z)}
+}
+
+func TestBuildFiltersCapsAndDedupes(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	feeds := map[string]*rss.RSS{
+		"leaders": feed("Leaders",
+			item("Old news", "https://www.economist.com/leaders/old", now.Add(-48*time.Hour)),
+			item("China's choice", "https://www.economist.com/leaders/china", now.Add(-2*time.Hour)),
+			item("Rates", "https://www.economist.com/leaders/rates", now.Add(-1*time.Hour)),
+		),
+		"china": feed("China",
+			item("China's choice", "https://www.economist.com/leaders/china", now.Add(-2*time.Hour)),
+			item("Exports", "https://www.economist.com/china/exports", now.Add(-3*time.Hour)),
+		),
+	}
+	fetched := 0
+	d, err := Build(Options{
+		Sections: []SectionSpec{{Name: "leaders", Limit: 1}, {Name: "china"}, {Name: "missing"}},
+		Since:    since,
+		Limit:    5,
+		Full:     true,
+	}, Fetcher{
+		Section: func(section string) (*rss.RSS, error) {
+			if f, ok := feeds[section]; ok {
+				return f, nil
+			}
+			return nil, errors.New("no such feed")
+		},
+		Article: func(url string) (*article.Article, error) {
+			fetched++
+			return &article.Article{URL: url, Blocks: article.ParagraphBlocks("Body of " + url)}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	if len(d.Sections) != 3 || d.Sections[2].Err == nil {
+		t.Fatalf("expected the missing feed kept with its error, got %#v", d.Sections)
+	}
+	leaders := d.Sections[0].Entries
+	if len(leaders) != 1 || leaders[0].Item.Title != "Rates" {
+		t.Fatalf("expected the newest leader only, got %#v", leaders)
+	}
+	china := d.Sections[1].Entries
+	if len(china) != 2 || china[0].Item.Title != "China's choice" {
+		t.Fatalf("expected china to keep both new items, got %#v", china)
+	}
+	if fetched != 3 || d.Count() != 3 {
+		t.Fatalf("expected 3 bodies fetched, got %d", fetched)
+	}
+}
+
+func TestBuildFailsWhenEveryFeedFails(t *testing.T) {
+	_, err := Build(Options{Sections: []SectionSpec{{Name: "leaders"}}}, Fetcher{
+		Section: func(string) (*rss.RSS, error) { return nil, errors.New("offline") },
+	})
+	if err == nil {
+		t.Fatalf("expected an error when nothing loads")
+	}
+}
+
+func TestParseSectionSpec(t *testing.T) {
+	if spec, err := ParseSectionSpec("finance:3"); err != nil || spec.Name != "finance" || spec.Limit != 3 {
+		t.Fatalf("unexpected spec %#v (err %v)", spec, err)
+	}
+	if _, err := ParseSectionSpec("finance:none"); err == nil {
+		t.Fatalf("expected an invalid cap to fail")
+	}
+}
+
+func TestRenderFormats(t *testing.T) {
+	d := &Digest{
+		Title: "Morning",
+		Since: time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC),
+		Sections: []Section{
+			{Name: "leaders", Title: "Leaders", Entries: []Entry{{Item: rss.Item{Title: "Rates & risk", Link: "https://www.economist.com/leaders/rates", Description: "Central banks hesitate"}}}},
+			{Name: "china", Title: "China"},
+		},
+	}
+
+	md := Markdown(d)
+	if !strings.Contains(md, "## Leaders") || !strings.Contains(md, "- [Rates & risk](https://www.economist.com/leaders/rates) — Central banks hesitate") || strings.Contains(md, "## China") {
+		t.Fatalf("unexpected markdown:\n%s", md)
+	}
+	text := Text(d)
+	if !strings.Contains(text, "LEADERS\n=======") || !strings.Contains(text, "1. Rates & risk") {
+		t.Fatalf("unexpected text:\n%s", text)
+	}
+	page := HTML(d)
+	if !strings.Contains(page, `<h2 class="section-title">Leaders</h2>`) || !strings.Contains(page, "Rates &amp; risk") {
+		t.Fatalf("unexpected html:\n%s", page)
+	}
+	if term := Terminal(d, true, 80); !strings.Contains(term, "1. Rates & risk") {
+		t.Fatalf("unexpected terminal output:\n%s", term)
+	}
+}
@@ -0,0 +1,142 @@
+package digest
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/ui"
+)
+
+const sinceLayout = "Mon 2 Jan 2006 15:04"
+
+func (d *Digest) sinceLine() string {
+	return fmt.Sprintf("%d articles since %s", d.Count(), d.Since.Local().Format(sinceLayout))
+}
+
+// sections returns the sections with something to show.
+func (d *Digest) sections() []Section {
+	var out []Section
+	for _, section := range d.Sections {
+		if len(section.Entries) > 0 {
+			out = append(out, section)
+		}
+	}
+	return out
+}
+
+// Markdown renders the digest with a heading per section and, when bodies
+// were fetched, each article's text under its headline.
+func Markdown(d *Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n_%s_\n", d.Title, d.sinceLine())
+	for _, section := range d.sections() {
+		fmt.Fprintf(&b, "\n## %s\n", section.Title)
+		for _, entry := range section.Entries {
+			if entry.Article == nil {
+				fmt.Fprintf(&b, "\n- [%s](%s)", entry.Item.CleanTitle(), strings.TrimSpace(entry.Item.Link))
+				if desc := entry.Item.CleanDescription(); desc != "" {
+					b.WriteString(" — " + desc)
+				}
+				b.WriteString("\n")
+				continue
+			}
+			fmt.Fprintf(&b, "\n### [%s](%s)\n\n", entry.Item.CleanTitle(), strings.TrimSpace(entry.Item.Link))
+			if entry.Article.Subtitle != "" {
+				fmt.Fprintf(&b, "*%s*\n\n", entry.Article.Subtitle)
+			}
+			b.WriteString(entry.Article.BodyMarkdown() + "\n")
+		}
+	}
+	return b.String()
+}
+
+// Text renders the digest as plain text, for email and notes that do not
+// read Markdown.
+func Text(d *Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n", d.Title, d.sinceLine())
+	for _, section := range d.sections() {
+		fmt.Fprintf(&b, "\n%s\n%s\n", strings.ToUpper(section.Title), strings.Repeat("=", len([]rune(section.Title))))
+		for i, entry := range section.Entries {
+			fmt.Fprintf(&b, "\n%d. %s\n", i+1, entry.Item.CleanTitle())
+			if desc := entry.Item.CleanDescription(); desc != "" {
+				fmt.Fprintf(&b, "   %s\n", desc)
+			}
+			fmt.Fprintf(&b, "   %s\n", strings.TrimSpace(entry.Item.Link))
+			if entry.Article != nil {
+				b.WriteString("\n" + entry.Article.BodyText() + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// HTML renders the digest as a standalone page in the reader's style.
+func HTML(d *Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<header>\n<h1>%s</h1>\n<p class=\"dateline\">%s</p>\n</header>\n",
+		html.EscapeString(d.Title), html.EscapeString(d.sinceLine()))
+	for _, section := range d.sections() {
+		fmt.Fprintf(&b, "<section>\n<h2 class=\"section-title\">%s</h2>\n", html.EscapeString(section.Title))
+		for i, entry := range section.Entries {
+			if entry.Article != nil {
+				b.WriteString(ui.ArticleHTMLSection(entry.Article, fmt.Sprintf("%s-%d", section.Name, i+1)))
+				continue
+			}
+			link := html.EscapeString(strings.TrimSpace(entry.Item.Link))
+			fmt.Fprintf(&b, "<h3><a href=\"%s\">%s</a></h3>\n", link, html.EscapeString(entry.Item.CleanTitle()))
+			if desc := entry.Item.CleanDescription(); desc != "" {
+				fmt.Fprintf(&b, "<p class=\"summary\">%s</p>\n", html.EscapeString(desc))
+			}
+		}
+		b.WriteString("</section>\n")
+	}
+	return ui.HTMLDocument(d.Title, b.String())
+}
+
+// Terminal renders the digest for the terminal at the reader's width.
+func Terminal(d *Digest, noColor bool, termWidth int) string {
+	width := ui.ReaderContentWidth(termWidth)
+	styles := ui.NewBrowseStyles(noColor)
+	accentStyles := ui.NewStyles(ui.CurrentTheme(), noColor)
+
+	var b strings.Builder
+	b.WriteString(styles.Header.Render(d.Title) + "\n")
+	b.WriteString(ui.AccentRule(width, accentStyles) + "\n")
+	b.WriteString(styles.Dim.Render(d.sinceLine()) + "\n")
+
+	sections := d.sections()
+	if len(sections) == 0 {
+		b.WriteString("\nNo new articles.\n")
+		return b.String()
+	}
+	for _, section := range sections {
+		b.WriteString("\n" + styles.Badge.Render(section.Title) + "\n")
+		b.WriteString(ui.SectionRule(width, accentStyles) + "\n")
+		numWidth := len(fmt.Sprintf("%d", len(section.Entries)))
+		pad := strings.Repeat(" ", numWidth+2)
+		for i, entry := range section.Entries {
+			for j, line := range ui.WrapLines(entry.Item.CleanTitle(), width-len(pad)) {
+				prefix := pad
+				if j == 0 {
+					prefix = fmt.Sprintf("%*d. ", numWidth, i+1)
+				}
+				b.WriteString(styles.Title.Render(prefix+line) + "\n")
+			}
+			for _, line := range ui.WrapLines(entry.Item.CleanDescription(), width-len(pad)) {
+				b.WriteString(pad + styles.Subtitle.Render(line) + "\n")
+			}
+			if entry.Article != nil {
+				for _, para := range strings.Split(entry.Article.BodyText(), "\n\n") {
+					b.WriteString("\n")
+					for _, line := range ui.WrapLines(para, width-len(pad)) {
+						b.WriteString(pad + line + "\n")
+					}
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
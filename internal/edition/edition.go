@@ -0,0 +1,172 @@
+package edition
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/browser"
+	"github.com/tmustier/economist-tui/internal/cache"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const (
+	baseURL      = "https://www.economist.com/weekly-edition"
+	dateLayout   = "2006-01-02"
+	cachePrefix  = "edition-"
+	httpTimeout  = 15 * time.Second
+	maxPageBytes = 8 << 20
+
+	// latestTTL bounds how long "this week's" table of contents is reused;
+	// a dated issue never changes once published.
+	latestTTL = time.Hour
+	issueTTL  = 30 * 24 * time.Hour
+)
+
+// Edition is the table of contents of one weekly print issue.
+type Edition struct {
+	Date     time.Time `json:"date"`
+	Title    string    `json:"title,omitempty"`
+	URL      string    `json:"url"`
+	Sections []Section `json:"sections"`
+}
+
+// Section is a print section with its articles in print order.
+type Section struct {
+	Name     string    `json:"name"`
+	Articles []Article `json:"articles"`
+}
+
+// Article is one entry in the table of contents.
+type Article struct {
+	Title    string `json:"title"`
+	Flytitle string `json:"flytitle,omitempty"`
+	Rubric   string `json:"rubric,omitempty"`
+	URL      string `json:"url"`
+}
+
+// ArticleCount returns the number of articles across all sections.
+func (e *Edition) ArticleCount() int {
+	count := 0
+	for _, section := range e.Sections {
+		count += len(section.Articles)
+	}
+	return count
+}
+
+// IssueDate returns the date an issue is known by: the Saturday on or
+// after the given day, since issues come out on Thursday dated Saturday.
+func IssueDate(day time.Time) time.Time {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(time.Saturday) - int(day.Weekday()) + 7) % 7
+	return day.AddDate(0, 0, offset)
+}
+
+// ParseDate parses a YYYY-MM-DD date argument into an issue date. An
+// empty argument means the latest issue and returns the zero time.
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, appErrors.NewUserError("invalid edition date %q - use YYYY-MM-DD", value)
+	}
+	return IssueDate(day), nil
+}
+
+// URL returns the weekly-edition page for an issue date, or for the
+// latest issue when date is zero.
+func URL(date time.Time) string {
+	if date.IsZero() {
+		return baseURL
+	}
+	return baseURL + "/" + date.Format(dateLayout)
+}
+
+type cacheEntry struct {
+	CachedAt time.Time `json:"cached_at"`
+	Edition  Edition   `json:"edition"`
+}
+
+func cachePath(date time.Time) string {
+	name := "latest.json"
+	if !date.IsZero() {
+		name = date.Format(dateLayout) + ".json"
+	}
+	return filepath.Join(cache.CacheDir(), cachePrefix+name)
+}
+
+// Fetch returns the table of contents for the issue date, or the latest
+// issue when date is zero. Pages are cached; a stale copy is returned
+// when the site cannot be reached.
+func Fetch(date time.Time) (*Edition, error) {
+	path := cachePath(date)
+	ttl := issueTTL
+	if date.IsZero() {
+		ttl = latestTTL
+	}
+
+	var cached cacheEntry
+	cachedOK, _ := store.ReadJSON(path, &cached)
+	if cachedOK && time.Since(cached.CachedAt) <= ttl {
+		return &cached.Edition, nil
+	}
+
+	ed, err := fetchEdition(URL(date))
+	if err != nil {
+		if cachedOK {
+			return &cached.Edition, nil
+		}
+		return nil, err
+	}
+
+	_ = store.WriteJSON(path, cacheEntry{CachedAt: time.Now().UTC(), Edition: *ed})
+	if date.IsZero() && !ed.Date.IsZero() {
+		_ = store.WriteJSON(cachePath(ed.Date), cacheEntry{CachedAt: time.Now().UTC(), Edition: *ed})
+	}
+	return ed, nil
+}
+
+func fetchEdition(pageURL string) (*Edition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", browser.UserAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, appErrors.NewUserError("no edition found at %s", pageURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, pageURL)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	// The undated page redirects to the current issue, whose URL carries
+	// the issue date.
+	ed, err := Parse(string(body), resp.Request.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	return ed, nil
+}
@@ -0,0 +1,133 @@
+package edition
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+)
+
+func loadFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return string(data)
+}
+
+func TestParseNextData(t *testing.T) {
+	ed, err := Parse(loadFixture(t, "nextdata.html"), "https://www.economist.com/weekly-edition")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if got := ed.Date.Format(dateLayout); got != "2026-10-10" {
+		t.Fatalf("expected issue date from canonical URL, got %s", got)
+	}
+	if ed.URL != "https://www.economist.com/weekly-edition/2026-10-10" {
+		t.Fatalf("expected canonical URL, got %q", ed.URL)
+	}
+	if len(ed.Sections) != 2 || ed.Sections[0].Name != "Leaders" || ed.Sections[1].Name != "Finance & economics" {
+		t.Fatalf("unexpected sections: %#v", ed.Sections)
+	}
+	if ed.ArticleCount() != 3 {
+		t.Fatalf("expected duplicates and untitled parts dropped, got %d articles", ed.ArticleCount())
+	}
+
+	first := ed.Sections[0].Articles[0]
+	if first.Flytitle != "Interest rates" || first.Rubric != "Inflation is proving stubborn" {
+		t.Fatalf("unexpected first article: %#v", first)
+	}
+	if got := ed.Sections[0].Articles[1].URL; got != "https://www.economist.com/leaders/2026/10/08/a-fragile-truce" {
+		t.Fatalf("expected relative URL resolved, got %q", got)
+	}
+}
+
+func TestParseHTMLFallback(t *testing.T) {
+	ed, err := Parse(loadFixture(t, "sections.html"), "https://www.economist.com/weekly-edition/2026-10-10")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if got := ed.Date.Format(dateLayout); got != "2026-10-10" {
+		t.Fatalf("expected issue date from page URL, got %s", got)
+	}
+	if len(ed.Sections) != 2 {
+		t.Fatalf("expected 2 sections, got %#v", ed.Sections)
+	}
+	britain := ed.Sections[1]
+	if britain.Name != "Britain" || len(britain.Articles) != 1 {
+		t.Fatalf("unexpected Britain section: %#v", britain)
+	}
+	if britain.Articles[0].Title != "The housing squeeze" {
+		t.Fatalf("expected whitespace collapsed, got %q", britain.Articles[0].Title)
+	}
+}
+
+func TestParseWithoutContents(t *testing.T) {
+	if _, err := Parse("<html><body><p>Nothing</p></body></html>", baseURL); err != ErrNoContents {
+		t.Fatalf("expected ErrNoContents, got %v", err)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	cases := map[string]string{
+		"2026-10-10": "2026-10-10",
+		"2026-10-08": "2026-10-10",
+		"2026-10-11": "2026-10-17",
+	}
+	for input, want := range cases {
+		got, err := ParseDate(input)
+		if err != nil {
+			t.Fatalf("parse %q: %v", input, err)
+		}
+		if got.Format(dateLayout) != want {
+			t.Fatalf("parse %q: expected %s, got %s", input, want, got.Format(dateLayout))
+		}
+	}
+
+	if got, err := ParseDate(""); err != nil || !got.IsZero() {
+		t.Fatalf("expected zero date for latest issue, got %v %v", got, err)
+	}
+	if _, err := ParseDate("10/10/2026"); !appErrors.IsUserError(err) {
+		t.Fatalf("expected user error, got %v", err)
+	}
+}
+
+func TestSourceSections(t *testing.T) {
+	ed := &Edition{
+		Date: time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+		Sections: []Section{
+			{Name: "Finance & economics", Articles: []Article{
+				{Title: "Bond markets wobble", Flytitle: "Debt", Rubric: "Yields climb again", URL: "https://www.economist.com/x"},
+			}},
+		},
+	}
+	source := NewSource(ed, false)
+
+	sections := source.Sections()
+	if len(sections) != 1 || sections[0].Primary != "finance-economics" {
+		t.Fatalf("unexpected sections: %#v", sections)
+	}
+
+	title, items, err := source.Section("finance-economics")
+	if err != nil {
+		t.Fatalf("section: %v", err)
+	}
+	if title != "Finance & economics · 10 October 2026" {
+		t.Fatalf("unexpected title %q", title)
+	}
+	if len(items) != 1 || items[0].Description != "Debt · Yields climb again" || items[0].Link != "https://www.economist.com/x" {
+		t.Fatalf("unexpected items: %#v", items)
+	}
+	if items[0].FormattedDate() == "" {
+		t.Fatalf("expected items dated by the issue")
+	}
+
+	if _, _, err := source.Section("science"); !appErrors.IsUserError(err) {
+		t.Fatalf("expected user error for unknown section, got %v", err)
+	}
+}
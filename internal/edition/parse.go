@@ -0,0 +1,236 @@
+package edition
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const siteURL = "https://www.economist.com"
+
+var (
+	editionPathPattern = regexp.MustCompile(`/weekly-edition/(\d{4}-\d{2}-\d{2})`)
+	articlePathPattern = regexp.MustCompile(`^/[a-z0-9-]+/\d{4}/\d{2}/\d{2}/[^/?#]+`)
+)
+
+// ErrNoContents means the page had no recognisable table of contents.
+var ErrNoContents = errors.New("no articles found on the weekly-edition page")
+
+// Parse reads the table of contents from a weekly-edition page. The
+// Next.js payload is preferred; the rendered sections are the fallback.
+func Parse(html, pageURL string) (*Edition, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, err
+	}
+
+	ed := &Edition{URL: pageURL}
+	if canonical := strings.TrimSpace(doc.Find("link[rel='canonical']").AttrOr("href", "")); canonical != "" {
+		ed.URL = canonical
+	}
+	ed.Date = editionDate(ed.URL, pageURL)
+	ed.Title = strings.TrimSpace(doc.Find("meta[property='og:title']").AttrOr("content", ""))
+
+	ed.Sections = nextDataSections(doc)
+	if len(ed.Sections) == 0 {
+		ed.Sections = htmlSections(doc)
+	}
+	if len(ed.Sections) == 0 {
+		return nil, ErrNoContents
+	}
+	return ed, nil
+}
+
+func editionDate(urls ...string) time.Time {
+	for _, u := range urls {
+		if m := editionPathPattern.FindStringSubmatch(u); m != nil {
+			if t, err := time.Parse(dateLayout, m[1]); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func nextDataSections(doc *goquery.Document) []Section {
+	script := doc.Find("script#__NEXT_DATA__").First()
+	if script.Length() == 0 {
+		return nil
+	}
+	var data any
+	if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+		return nil
+	}
+	parts := findParts(data)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	var b sectionBuilder
+	for _, part := range parts {
+		obj, ok := part.(map[string]any)
+		if !ok {
+			continue
+		}
+		art := Article{
+			Title:    jsonString(obj["headline"]),
+			Flytitle: firstNonEmpty(jsonString(obj["flyTitle"]), jsonString(obj["subheadline"])),
+			Rubric:   firstNonEmpty(jsonString(obj["rubric"]), jsonString(obj["description"])),
+			URL:      resolveURL(jsonURL(obj["url"])),
+		}
+		b.add(partSection(obj), art)
+	}
+	return b.sections
+}
+
+// findParts returns the first list of issue articles in the payload: the
+// "parts" of an object's "hasPart".
+func findParts(value any) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if hasPart, ok := v["hasPart"].(map[string]any); ok {
+			if parts, ok := hasPart["parts"].([]any); ok && len(parts) > 0 {
+				return parts
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if parts := findParts(v[key]); parts != nil {
+				return parts
+			}
+		}
+	case []any:
+		for _, child := range v {
+			if parts := findParts(child); parts != nil {
+				return parts
+			}
+		}
+	}
+	return nil
+}
+
+// partSection returns the print section of an issue article, falling
+// back to its web section.
+func partSection(obj map[string]any) string {
+	if print, ok := obj["print"].(map[string]any); ok {
+		if section, ok := print["section"].(map[string]any); ok {
+			if name := jsonString(section["headline"]); name != "" {
+				return name
+			}
+		}
+	}
+	switch section := obj["articleSection"].(type) {
+	case string:
+		return strings.TrimSpace(section)
+	case map[string]any:
+		if internal, ok := section["internal"].([]any); ok && len(internal) > 0 {
+			if first, ok := internal[0].(map[string]any); ok {
+				return jsonString(first["headline"])
+			}
+		}
+	}
+	return ""
+}
+
+func htmlSections(doc *goquery.Document) []Section {
+	var b sectionBuilder
+	doc.Find("section").Each(func(_ int, sel *goquery.Selection) {
+		name := strings.TrimSpace(sel.Find("h2").First().Text())
+		if name == "" {
+			return
+		}
+		sel.Find("a[href]").Each(func(_ int, link *goquery.Selection) {
+			href := link.AttrOr("href", "")
+			path := strings.TrimPrefix(href, siteURL)
+			if !articlePathPattern.MatchString(path) {
+				return
+			}
+			title := strings.TrimSpace(link.Closest("h3").Text())
+			if title == "" {
+				title = strings.TrimSpace(link.Text())
+			}
+			b.add(name, Article{Title: strings.Join(strings.Fields(title), " "), URL: resolveURL(href)})
+		})
+	})
+	return b.sections
+}
+
+// sectionBuilder groups articles into sections in order of first
+// appearance, dropping repeated URLs.
+type sectionBuilder struct {
+	sections []Section
+	index    map[string]int
+	seen     map[string]bool
+}
+
+func (b *sectionBuilder) add(name string, art Article) {
+	if art.Title == "" || art.URL == "" {
+		return
+	}
+	if b.index == nil {
+		b.index = make(map[string]int)
+		b.seen = make(map[string]bool)
+	}
+	if b.seen[art.URL] {
+		return
+	}
+	b.seen[art.URL] = true
+
+	if name == "" {
+		name = "Other"
+	}
+	i, ok := b.index[name]
+	if !ok {
+		i = len(b.sections)
+		b.index[name] = i
+		b.sections = append(b.sections, Section{Name: name})
+	}
+	b.sections[i].Articles = append(b.sections[i].Articles, art)
+}
+
+func resolveURL(href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return ""
+	}
+	base, _ := url.Parse(siteURL)
+	ref, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+func jsonString(value any) string {
+	s, _ := value.(string)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func jsonURL(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		return firstNonEmpty(jsonString(v["canonical"]), jsonString(v["url"]))
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
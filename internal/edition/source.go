@@ -0,0 +1,96 @@
+package edition
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+// Source serves an issue's sections, in print order, to the browse TUI.
+type Source struct {
+	edition *Edition
+	debug   bool
+}
+
+func NewSource(ed *Edition, debug bool) Source {
+	return Source{edition: ed, debug: debug}
+}
+
+// SectionKey returns the browse section name for a print section, e.g.
+// "Finance & economics" becomes "finance-economics".
+func SectionKey(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+func (s Source) Sections() []rss.SectionInfo {
+	sections := make([]rss.SectionInfo, 0, len(s.edition.Sections))
+	for _, section := range s.edition.Sections {
+		key := SectionKey(section.Name)
+		sections = append(sections, rss.SectionInfo{Primary: key, Path: key, Aliases: []string{key}})
+	}
+	return sections
+}
+
+func (s Source) Section(name string) (string, []rss.Item, error) {
+	key := SectionKey(name)
+	for _, section := range s.edition.Sections {
+		if SectionKey(section.Name) == key {
+			return s.sectionTitle(section), s.items(section), nil
+		}
+	}
+	return "", nil, appErrors.NewUserError("no %q section in this edition", name)
+}
+
+func (s Source) Article(url string) (*article.Article, error) {
+	return fetch.FetchArticle(url, fetch.Options{Debug: s.debug})
+}
+
+func (s Source) sectionTitle(section Section) string {
+	if s.edition.Date.IsZero() {
+		return section.Name
+	}
+	return section.Name + " · " + s.edition.Date.Format("2 January 2006")
+}
+
+// items lists the section's articles, dated by the issue since the
+// contents page carries no per-article dates.
+func (s Source) items(section Section) []rss.Item {
+	pubDate := ""
+	if !s.edition.Date.IsZero() {
+		pubDate = s.edition.Date.Format(time.RFC1123Z)
+	}
+	items := make([]rss.Item, 0, len(section.Articles))
+	for _, art := range section.Articles {
+		description := art.Rubric
+		if art.Flytitle != "" && description != "" {
+			description = art.Flytitle + " · " + description
+		} else if description == "" {
+			description = art.Flytitle
+		}
+		items = append(items, rss.Item{
+			Title:       art.Title,
+			Description: description,
+			Link:        art.URL,
+			GUID:        art.URL,
+			PubDate:     pubDate,
+		})
+	}
+	return items
+}
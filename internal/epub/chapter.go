@@ -0,0 +1,51 @@
+package epub
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func escape(text string) string {
+	return html.EscapeString(text)
+}
+
+// chapterDocument renders one article as an XHTML content document, with
+// the headline block as a header above the body.
+func chapterDocument(art *article.Article, language string) string {
+	var header strings.Builder
+	if art.Overtitle != "" {
+		fmt.Fprintf(&header, "    <p class=\"overtitle\">%s</p>\n", escape(art.Overtitle))
+	}
+	fmt.Fprintf(&header, "    <h1>%s</h1>\n", escape(chapterTitle(art)))
+	if art.Subtitle != "" {
+		fmt.Fprintf(&header, "    <p class=\"subtitle\">%s</p>\n", escape(art.Subtitle))
+	}
+	if art.DateLine != "" {
+		fmt.Fprintf(&header, "    <p class=\"dateline\">%s</p>\n", escape(art.DateLine))
+	}
+	if byline := art.BylineLine(); byline != "" {
+		fmt.Fprintf(&header, "    <p class=\"byline\">%s</p>\n", escape(byline))
+	}
+
+	source := escape(art.SourceURL())
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="%[1]s" lang="%[1]s">
+<head>
+  <title>%[2]s</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+  <article>
+  <header>
+%[3]s  </header>
+%[4]s
+  <p class="source"><a href="%[5]s">%[5]s</a></p>
+  </article>
+</body>
+</html>
+`, escape(language), escape(chapterTitle(art)), header.String(), art.BodyHTML(), source)
+}
@@ -0,0 +1,205 @@
+package epub
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+)
+
+const (
+	defaultLanguage  = "en-GB"
+	defaultPublisher = "The Economist"
+	modifiedLayout   = "2006-01-02T15:04:05Z"
+)
+
+// Book is a set of articles bound as one EPUB, one chapter per article in
+// the order given.
+type Book struct {
+	Title    string
+	Language string    // BCP 47 tag; defaults to en-GB
+	Modified time.Time // defaults to now
+	Articles []*article.Article
+}
+
+// Write writes the book as an EPUB 3 container. Chapters carry the
+// article's overtitle, subtitle and dateline; the navigation document and
+// an NCX table of contents are generated from the chapter titles.
+func Write(w io.Writer, book Book) error {
+	if len(book.Articles) == 0 {
+		return appErrors.NewUserError("nothing to export - no articles")
+	}
+	if book.Title == "" {
+		book.Title = book.Articles[0].Title
+	}
+	if book.Language == "" {
+		book.Language = defaultLanguage
+	}
+	if book.Modified.IsZero() {
+		book.Modified = time.Now()
+	}
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype must come first and be stored uncompressed so readers
+	// can identify the file from its leading bytes.
+	mimetype, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/container.xml", containerXML},
+		{"OEBPS/content.opf", packageDocument(book)},
+		{"OEBPS/nav.xhtml", navDocument(book)},
+		{"OEBPS/toc.ncx", ncxDocument(book)},
+		{"OEBPS/style.css", stylesheet},
+	}
+	for i, art := range book.Articles {
+		files = append(files, struct {
+			name    string
+			content string
+		}{"OEBPS/" + chapterFile(i), chapterDocument(art, book.Language)})
+	}
+
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, file.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func chapterFile(index int) string {
+	return fmt.Sprintf("chapter-%03d.xhtml", index+1)
+}
+
+// identifier derives a stable URN from the article URLs, so exporting the
+// same articles again replaces the book on readers that track identity.
+func identifier(book Book) string {
+	h := sha1.New()
+	for _, art := range book.Articles {
+		io.WriteString(h, art.URL+"\n")
+	}
+	sum := h.Sum(nil)
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func packageDocument(book Book) string {
+	var manifest, spine strings.Builder
+	for i := range book.Articles {
+		id := fmt.Sprintf("chapter-%03d", i+1)
+		fmt.Fprintf(&manifest, "    <item id=\"%s\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", id, chapterFile(i))
+		fmt.Fprintf(&spine, "    <itemref idref=\"%s\"/>\n", id)
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="%[3]s">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">%[1]s</dc:identifier>
+    <dc:title>%[2]s</dc:title>
+    <dc:language>%[3]s</dc:language>
+    <dc:publisher>%[4]s</dc:publisher>
+    <meta property="dcterms:modified">%[5]s</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="style" href="style.css" media-type="text/css"/>
+%[6]s  </manifest>
+  <spine toc="ncx">
+%[7]s  </spine>
+</package>
+`, identifier(book), escape(book.Title), escape(book.Language), defaultPublisher,
+		book.Modified.UTC().Format(modifiedLayout), manifest.String(), spine.String())
+}
+
+func navDocument(book Book) string {
+	var items strings.Builder
+	for i, art := range book.Articles {
+		fmt.Fprintf(&items, "      <li><a href=\"%s\">%s</a></li>\n", chapterFile(i), escape(chapterTitle(art)))
+	}
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="%[1]s" lang="%[1]s">
+<head>
+  <title>%[2]s</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+  <nav epub:type="toc" id="toc">
+    <h1>%[2]s</h1>
+    <ol>
+%[3]s    </ol>
+  </nav>
+</body>
+</html>
+`, escape(book.Language), escape(book.Title), items.String())
+}
+
+// ncxDocument is the EPUB 2 table of contents, still read by older
+// e-readers that ignore the navigation document.
+func ncxDocument(book Book) string {
+	var points strings.Builder
+	for i, art := range book.Articles {
+		fmt.Fprintf(&points, `    <navPoint id="nav-%[1]d" playOrder="%[1]d">
+      <navLabel><text>%[2]s</text></navLabel>
+      <content src="%[3]s"/>
+    </navPoint>
+`, i+1, escape(chapterTitle(art)), chapterFile(i))
+	}
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+    <meta name="dtb:uid" content="%s"/>
+  </head>
+  <docTitle><text>%s</text></docTitle>
+  <navMap>
+%s  </navMap>
+</ncx>
+`, identifier(book), escape(book.Title), points.String())
+}
+
+func chapterTitle(art *article.Article) string {
+	if art.Title != "" {
+		return art.Title
+	}
+	return art.URL
+}
+
+const stylesheet = `body { font-family: Georgia, serif; line-height: 1.5; margin: 0 1em; }
+h1 { font-size: 1.6em; line-height: 1.2; margin: 0.3em 0; }
+h2 { font-size: 1.2em; margin: 1.2em 0 0.4em; }
+.overtitle { color: #e3120b; font-size: 0.85em; font-weight: bold; margin: 1em 0 0; }
+.subtitle { font-size: 1.1em; font-style: italic; margin: 0 0 0.6em; }
+.dateline, .byline { color: #595959; font-size: 0.85em; margin: 0; }
+header { border-bottom: 1px solid #e3120b; margin-bottom: 1.2em; padding-bottom: 0.6em; }
+blockquote { border-left: 3px solid #e3120b; margin: 1em 0; padding-left: 1em; }
+figcaption { color: #595959; font-size: 0.85em; }
+table { border-collapse: collapse; font-size: 0.9em; }
+th, td { border-bottom: 1px solid #d9d9d9; padding: 0.2em 0.5em; text-align: left; }
+.source { color: #595959; font-size: 0.8em; margin-top: 2em; word-wrap: break-word; }
+`
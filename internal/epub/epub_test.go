@@ -0,0 +1,116 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func readEntries(t *testing.T, data []byte) ([]*zip.File, map[string]string) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	contents := make(map[string]string, len(zr.File))
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[file.Name] = string(body)
+	}
+	return zr.File, contents
+}
+
+func TestWriteBook(t *testing.T) {
+	articles := []*article.Article{
+		{
+			Overtitle: "Finance & economics | Bonds",
+			Title:     "Yields <rise>",
+			Subtitle:  "Investors are nervous",
+			DateLine:  "Oct 15th 2026",
+			URL:       "https://www.economist.com/finance/bonds",
+			Blocks: []article.Block{
+				{Kind: article.BlockParagraph, Text: "See the rates story.", Spans: []article.Span{
+					{Text: "See the "},
+					{Text: "rates story", URL: "https://www.economist.com/rates?a=1&b=2"},
+					{Text: "."},
+				}},
+				{Kind: article.BlockHeading, Text: "Next", Level: 2},
+				{Kind: article.BlockList, Items: []string{"one", "two"}},
+				{Kind: article.BlockRule},
+			},
+		},
+		{Title: "China's exports", URL: "https://www.economist.com/china/exports", Blocks: article.ParagraphBlocks("Ships left.")},
+	}
+
+	var buf bytes.Buffer
+	modified := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	if err := Write(&buf, Book{Title: "Commute", Modified: modified, Articles: articles}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	files, contents := readEntries(t, buf.Bytes())
+	if files[0].Name != "mimetype" || files[0].Method != zip.Store || contents["mimetype"] != "application/epub+zip" {
+		t.Fatalf("expected a stored mimetype first, got %s (method %d)", files[0].Name, files[0].Method)
+	}
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/toc.ncx", "OEBPS/chapter-001.xhtml", "OEBPS/chapter-002.xhtml"} {
+		body, ok := contents[name]
+		if !ok {
+			t.Fatalf("missing %s", name)
+		}
+		decoder := xml.NewDecoder(strings.NewReader(body))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed: %v", name, err)
+			}
+		}
+	}
+
+	chapter := contents["OEBPS/chapter-001.xhtml"]
+	for _, want := range []string{
+		`<p class="overtitle">Finance &amp; economics | Bonds</p>`,
+		"<h1>Yields &lt;rise&gt;</h1>",
+		`<p class="subtitle">Investors are nervous</p>`,
+		`<p class="dateline">Oct 15th 2026</p>`,
+		`<a href="https://www.economist.com/rates?a=1&amp;b=2">rates story</a>`,
+		"<ul><li>one</li><li>two</li></ul>",
+	} {
+		if !strings.Contains(chapter, want) {
+			t.Fatalf("expected %q in chapter:\n%s", want, chapter)
+		}
+	}
+
+	nav := contents["OEBPS/nav.xhtml"]
+	if !strings.Contains(nav, `<a href="chapter-002.xhtml">China&#39;s exports</a>`) {
+		t.Fatalf("expected the second chapter in the table of contents:\n%s", nav)
+	}
+	opf := contents["OEBPS/content.opf"]
+	if !strings.Contains(opf, "<dc:title>Commute</dc:title>") || !strings.Contains(opf, "2026-10-16T08:00:00Z") {
+		t.Fatalf("unexpected package document:\n%s", opf)
+	}
+
+	var again bytes.Buffer
+	_ = Write(&again, Book{Title: "Commute", Articles: articles})
+	_, againContents := readEntries(t, again.Bytes())
+	if id := identifier(Book{Articles: articles}); !strings.Contains(opf, id) || !strings.Contains(againContents["OEBPS/content.opf"], id) {
+		t.Fatalf("expected a stable identifier across exports")
+	}
+}
+
+func TestWriteRequiresArticles(t *testing.T) {
+	if err := Write(io.Discard, Book{Title: "Empty"}); err == nil {
+		t.Fatalf("expected an error for an empty book")
+	}
+}
@@ -0,0 +1,59 @@
+package fetch
+
+import "github.com/tmustier/economist-tui/internal/article"
+
+// DefaultWorkers is how many articles a batch fetches at once by default.
+const DefaultWorkers = 4
+
+// Result is the outcome of fetching one URL of a batch.
+type Result struct {
+	Index   int
+	URL     string
+	Article *article.Article
+	Err     error
+}
+
+// FetchBatch fetches urls with at most workers in flight. Results arrive in
+// the order of urls, each as soon as it and those before it are done, and a
+// failed URL does not stop the others. The channel must be drained.
+func FetchBatch(urls []string, workers int, opts Options) <-chan Result {
+	return fetchBatch(urls, workers, func(url string) (*article.Article, error) {
+		return FetchArticle(url, opts)
+	})
+}
+
+func fetchBatch(urls []string, workers int, fetchOne func(string) (*article.Article, error)) <-chan Result {
+	if workers < 1 {
+		workers = 1
+	}
+	workers = min(workers, len(urls))
+
+	pending := make([]chan Result, len(urls))
+	for i := range pending {
+		pending[i] = make(chan Result, 1)
+	}
+	jobs := make(chan int)
+	go func() {
+		for i := range urls {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+	for range workers {
+		go func() {
+			for i := range jobs {
+				art, err := fetchOne(urls[i])
+				pending[i] <- Result{Index: i, URL: urls[i], Article: art, Err: err}
+			}
+		}()
+	}
+
+	out := make(chan Result)
+	go func() {
+		defer close(out)
+		for _, result := range pending {
+			out <- <-result
+		}
+	}()
+	return out
+}
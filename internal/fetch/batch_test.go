@@ -0,0 +1,59 @@
+package fetch
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestFetchBatchKeepsOrderAndBoundsWorkers(t *testing.T) {
+	urls := []string{"a", "b", "bad", "c", "d", "e"}
+	var inFlight, peak atomic.Int32
+	results := fetchBatch(urls, 2, func(url string) (*article.Article, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// Earlier URLs finish last, so ordering cannot come from timing.
+		time.Sleep(time.Duration(len(urls)-slices.Index(urls, url)) * 2 * time.Millisecond)
+		if url == "bad" {
+			return nil, errors.New("paywall")
+		}
+		return &article.Article{URL: url}, nil
+	})
+
+	var got []string
+	for result := range results {
+		if result.URL != urls[result.Index] {
+			t.Fatalf("result %d carries url %q", result.Index, result.URL)
+		}
+		if result.URL == "bad" {
+			if result.Err == nil {
+				t.Fatalf("expected an error for the bad url")
+			}
+		} else if result.Err != nil || result.Article.URL != result.URL {
+			t.Fatalf("unexpected result for %s: %v", result.URL, result.Err)
+		}
+		got = append(got, result.URL)
+	}
+
+	if len(got) != len(urls) {
+		t.Fatalf("expected %d results, got %v", len(urls), got)
+	}
+	for i := range urls {
+		if got[i] != urls[i] {
+			t.Fatalf("expected input order, got %v", got)
+		}
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 fetches at once, saw %d", peak.Load())
+	}
+}
@@ -14,13 +14,18 @@ import (
 	"github.com/tmustier/economist-tui/internal/daemon"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 	"github.com/tmustier/economist-tui/internal/logging"
+	"github.com/tmustier/economist-tui/internal/search"
 )
 
 type Options struct {
 	Debug bool
 }
 
-var purgeOnce sync.Once
+var (
+	purgeOnce sync.Once
+	// startMu keeps concurrent fetches in a batch from each starting a daemon.
+	startMu sync.Mutex
+)
 
 func FetchArticle(url string, opts Options) (*article.Article, error) {
 	logging.Debugf(opts.Debug, "read: start url=%s", url)
@@ -39,6 +44,17 @@ func FetchArticle(url string, opts Options) (*article.Article, error) {
 		}
 	}
 
+	httpArt, httpErr := fetchHTTP(url, opts)
+	if httpErr == nil {
+		logging.Debugf(opts.Debug, "read: http fetch ok")
+		art, err := validateArticle(httpArt)
+		if err != nil {
+			return nil, err
+		}
+		return cacheArticle(art, opts)
+	}
+	logging.Debugf(opts.Debug, "read: http fetch insufficient, using browser: %v", httpErr)
+
 	art, err := fetchViaDaemon(url, opts)
 	if err == nil {
 		logging.Debugf(opts.Debug, "read: daemon fetch ok")
@@ -50,13 +66,13 @@ func FetchArticle(url string, opts Options) (*article.Article, error) {
 	}
 	if !errors.Is(err, daemon.ErrNotRunning) {
 		logging.Debugf(opts.Debug, "read: daemon fetch error: %v", err)
-		return nil, err
+		return httpFallback(httpArt, httpErr, err)
 	}
 
 	logging.Debugf(opts.Debug, "read: daemon unavailable, using local fetch")
 	art, err = fetchLocal(url, opts)
 	if err != nil {
-		return nil, err
+		return httpFallback(httpArt, httpErr, err)
 	}
 
 	art, err = validateArticle(art)
@@ -66,8 +82,13 @@ func FetchArticle(url string, opts Options) (*article.Article, error) {
 	return cacheArticle(art, opts)
 }
 
+// daemonFetchTimeout is how long a read waits on the daemon. When Chrome
+// dies under a fetch the daemon relaunches it and retries once, so the
+// wait covers both attempts and the relaunch between them.
+const daemonFetchTimeout = 2*browser.FetchTimeout + browser.RelaunchTimeout
+
 func fetchViaDaemon(url string, opts Options) (*article.Article, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), browser.FetchTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), daemonFetchTimeout)
 	defer cancel()
 
 	logging.Debugf(opts.Debug, "read: trying daemon fetch")
@@ -82,11 +103,13 @@ func fetchViaDaemon(url string, opts Options) (*article.Article, error) {
 	}
 
 	logging.Debugf(opts.Debug, "read: daemon not running, starting background")
+	startMu.Lock()
 	_ = daemon.EnsureBackground()
-
 	readyCtx, readyCancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer readyCancel()
-	if daemon.WaitForReady(readyCtx, 200*time.Millisecond) {
+	ready := daemon.WaitForReady(readyCtx, 200*time.Millisecond)
+	readyCancel()
+	startMu.Unlock()
+	if ready {
 		logging.Debugf(opts.Debug, "read: daemon ready, retry fetch")
 		art, err = daemon.Fetch(ctx, url, opts.Debug)
 		if err == nil {
@@ -114,14 +137,45 @@ func fetchLocal(url string, opts Options) (*article.Article, error) {
 	return art, nil
 }
 
+// fetchHTTP tries a plain HTTP fetch, which is enough whenever the saved
+// cookies get the full article server-rendered.
+func fetchHTTP(url string, opts Options) (*article.Article, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	start := time.Now()
+	art, err := article.FetchHTTPWithCookies(url, article.FetchOptions{Debug: opts.Debug}, cfg.Cookies)
+	logging.Debugf(opts.Debug, "read: http fetch in %s", time.Since(start))
+	return art, err
+}
+
+// httpFallback picks the result to return when the browser fetch failed
+// after an insufficient HTTP fetch. A short article beats a browser error,
+// e.g. when Chrome is not installed; a paywall stub does not. The short
+// article is not cached so a later read can still complete it.
+func httpFallback(httpArt *article.Article, httpErr, browserErr error) (*article.Article, error) {
+	if errors.Is(httpErr, article.ErrIncomplete) && httpArt != nil && httpArt.HasBody() {
+		return httpArt, nil
+	}
+	if appErrors.IsPaywallError(httpErr) {
+		return nil, normalizeError(httpErr)
+	}
+	return nil, browserErr
+}
+
 func validateArticle(art *article.Article) (*article.Article, error) {
-	if art.Content == "" {
+	if !art.HasBody() {
 		return nil, appErrors.NewUserError("no article content found - try 'economist login'")
 	}
 	return art, nil
 }
 
 func cacheArticle(art *article.Article, opts Options) (*article.Article, error) {
+	if err := search.IndexArticle(art); err != nil {
+		logging.Debugf(opts.Debug, "read: search index error: %v", err)
+	}
 	if opts.Debug {
 		return art, nil
 	}
@@ -140,3 +194,34 @@ func normalizeError(err error) error {
 	}
 	return err
 }
+
+// Prefetch asks the daemon to fetch the articles into the cache, so that
+// reading them later is instant. It is best effort: the daemon is started
+// if need be, and errors are only logged.
+func Prefetch(urls []string, opts Options) {
+	var todo []string
+	for _, url := range urls {
+		if _, ok, _ := cache.LoadArticle(url); !ok {
+			todo = append(todo, url)
+		}
+	}
+	if len(todo) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	err := daemon.Prefetch(ctx, todo)
+	if errors.Is(err, daemon.ErrNotRunning) {
+		startMu.Lock()
+		_ = daemon.EnsureBackground()
+		ready := daemon.WaitForReady(ctx, 200*time.Millisecond)
+		startMu.Unlock()
+		if ready {
+			err = daemon.Prefetch(ctx, todo)
+		}
+	}
+	if err != nil {
+		logging.Debugf(opts.Debug, "prefetch: %v", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/browser"
 	appErrors "github.com/tmustier/economist-tui/internal/errors"
 )
 
@@ -29,3 +31,29 @@ func TestNormalizeErrorPassThrough(t *testing.T) {
 		t.Fatalf("expected user error pass-through")
 	}
 }
+
+func TestHTTPFallback(t *testing.T) {
+	browserErr := errors.New("chrome not found")
+	short := &article.Article{Blocks: article.ParagraphBlocks("A short but complete piece.")}
+
+	art, err := httpFallback(short, article.ErrIncomplete, browserErr)
+	if err != nil || art != short {
+		t.Fatalf("expected short article, got %v, %v", art, err)
+	}
+
+	if _, err := httpFallback(short, appErrors.PaywallError{}, browserErr); !appErrors.IsUserError(err) {
+		t.Fatalf("expected paywall user error, got %v", err)
+	}
+
+	if _, err := httpFallback(nil, errors.New("HTTP 500"), browserErr); err != browserErr {
+		t.Fatalf("expected browser error, got %v", err)
+	}
+}
+
+func TestDaemonFetchTimeoutCoversRetry(t *testing.T) {
+	// The daemon retries a fetch once after relaunching a crashed Chrome;
+	// a client that gives up sooner never sees the retry succeed.
+	if min := 2*browser.FetchTimeout + browser.RelaunchTimeout; daemonFetchTimeout < min {
+		t.Fatalf("expected the daemon fetch timeout to be at least %s, got %s", min, daemonFetchTimeout)
+	}
+}
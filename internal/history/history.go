@@ -0,0 +1,147 @@
+package history
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const (
+	historyDirName  = "history"
+	historyFileName = "history.json"
+
+	// maxEntries bounds the history; the oldest entries are dropped first.
+	maxEntries = 500
+)
+
+// Entry records one article read in the browse reader. Scroll is how far
+// down the article the reader got, as a fraction of the scrollable
+// length, so it survives a change of terminal size.
+type Entry struct {
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Section   string    `json:"section,omitempty"`
+	OpenedAt  time.Time `json:"opened_at"`
+	Scroll    float64   `json:"scroll"`
+	Completed bool      `json:"completed"`
+}
+
+// Resume returns the scroll fraction to reopen the article at: where the
+// reader left off, or the top once the article has been finished.
+func (e Entry) Resume() float64 {
+	if e.Completed {
+		return 0
+	}
+	return e.Scroll
+}
+
+var mu sync.Mutex
+
+func Path() string {
+	return filepath.Join(config.ConfigDir(), historyDirName, historyFileName)
+}
+
+// List returns the history, most recently opened first.
+func List() ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	return load()
+}
+
+// Lookup returns the history entry for the URL.
+func Lookup(url string) (Entry, bool, error) {
+	entries, err := List()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	for _, entry := range entries {
+		if entry.URL == url {
+			return entry, true, nil
+		}
+	}
+	return Entry{}, false, nil
+}
+
+// Opened records that the article was opened now and returns its entry,
+// which keeps the scroll position of any earlier visit.
+func Opened(url, title, section string) (Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{URL: url}
+	if i := indexOf(entries, url); i >= 0 {
+		entry = entries[i]
+		entries = append(entries[:i], entries[i+1:]...)
+	}
+	entry.Title = title
+	if section != "" {
+		entry.Section = section
+	}
+	entry.OpenedAt = time.Now().UTC()
+	entries = append([]Entry{entry}, entries...)
+	return entry, save(entries)
+}
+
+// SaveProgress records how far the article was read. Once completed, an
+// article stays completed.
+func SaveProgress(url string, scroll float64, completed bool) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries, err := load()
+	if err != nil {
+		return err
+	}
+	i := indexOf(entries, url)
+	if i < 0 {
+		return nil
+	}
+	entries[i].Scroll = clampFraction(scroll)
+	entries[i].Completed = entries[i].Completed || completed
+	return save(entries)
+}
+
+func load() ([]Entry, error) {
+	var entries []Entry
+	if _, err := store.ReadJSON(Path(), &entries); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OpenedAt.After(entries[j].OpenedAt)
+	})
+	return entries, nil
+}
+
+func save(entries []Entry) error {
+	if len(entries) > maxEntries {
+		entries = entries[:maxEntries]
+	}
+	return store.WriteJSON(Path(), entries)
+}
+
+func indexOf(entries []Entry, url string) int {
+	for i, entry := range entries {
+		if entry.URL == url {
+			return i
+		}
+	}
+	return -1
+}
+
+func clampFraction(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	if value > 1 {
+		return 1
+	}
+	return value
+}
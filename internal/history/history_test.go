@@ -0,0 +1,68 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpenedKeepsScrollAndOrder(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := Opened("https://www.economist.com/a", "First", "briefing"); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := SaveProgress("https://www.economist.com/a", 0.4, false); err != nil {
+		t.Fatalf("progress: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if _, err := Opened("https://www.economist.com/b", "Second", "leaders"); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	entry, err := Opened("https://www.economist.com/a", "First", "")
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if entry.Resume() != 0.4 || entry.Section != "briefing" {
+		t.Fatalf("expected earlier scroll and section kept, got %#v", entry)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 2 || entries[0].URL != "https://www.economist.com/a" {
+		t.Fatalf("expected reopened article first, got %#v", entries)
+	}
+}
+
+func TestCompletedStaysCompleted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	url := "https://www.economist.com/a"
+	if _, err := Opened(url, "First", ""); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := SaveProgress(url, 1.5, true); err != nil {
+		t.Fatalf("progress: %v", err)
+	}
+	if err := SaveProgress(url, 0.2, false); err != nil {
+		t.Fatalf("progress: %v", err)
+	}
+
+	entry, ok, err := Lookup(url)
+	if err != nil || !ok {
+		t.Fatalf("lookup: ok=%v err=%v", ok, err)
+	}
+	if !entry.Completed || entry.Scroll != 0.2 || entry.Resume() != 0 {
+		t.Fatalf("expected completed entry to reopen at the top, got %#v", entry)
+	}
+
+	if err := SaveProgress("https://www.economist.com/unknown", 0.5, false); err != nil {
+		t.Fatalf("progress for unknown URL: %v", err)
+	}
+	if _, ok, _ := Lookup("https://www.economist.com/unknown"); ok {
+		t.Fatalf("expected progress alone not to add an entry")
+	}
+}
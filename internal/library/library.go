@@ -0,0 +1,148 @@
+package library
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/config"
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const libraryDirName = "library"
+
+// Entry is a saved article. Unlike the article cache, entries never
+// expire; they stay until removed.
+type Entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Article article.Article `json:"article"`
+}
+
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), libraryDirName)
+}
+
+func entryPath(url string) string {
+	return filepath.Join(Dir(), store.KeyName(url))
+}
+
+// Save adds the article to the library, replacing any earlier copy but
+// keeping its original save time.
+func Save(art *article.Article) error {
+	entry := Entry{SavedAt: time.Now().UTC(), Article: *art}
+	entry.Article.DebugHTMLPath = ""
+	if existing, ok, err := Load(art.URL); err == nil && ok {
+		entry.SavedAt = existing.SavedAt
+	}
+	return store.WriteJSON(entryPath(art.URL), entry)
+}
+
+// Load returns the saved entry for the URL.
+func Load(url string) (*Entry, bool, error) {
+	var entry Entry
+	ok, err := store.ReadJSON(entryPath(url), &entry)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return &entry, true, nil
+}
+
+// Contains reports whether the URL is saved.
+func Contains(url string) bool {
+	_, err := os.Stat(entryPath(url))
+	return err == nil
+}
+
+// Remove deletes the saved entry. It reports whether one existed.
+func Remove(url string) (bool, error) {
+	return store.Remove(entryPath(url))
+}
+
+// List returns every saved entry, most recently saved first.
+func List() ([]Entry, error) {
+	files, err := os.ReadDir(Dir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		var entry Entry
+		if ok, err := store.ReadJSON(filepath.Join(Dir(), file.Name()), &entry); err != nil || !ok {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].SavedAt.After(entries[j].SavedAt)
+	})
+	return entries, nil
+}
+
+// Search returns the entries whose title, subtitle or body contain every
+// word of the query, ignoring case.
+func Search(query string) ([]Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return entries, nil
+	}
+
+	var matches []Entry
+	for _, entry := range entries {
+		art := entry.Article
+		text := strings.ToLower(art.Title + "\n" + art.Subtitle + "\n" + art.BodyText())
+		if containsAll(text, terms) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches, nil
+}
+
+func containsAll(text string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolve finds an entry by URL or by its 1-based position in List.
+func Resolve(ref string) (*Entry, error) {
+	ref = strings.TrimSpace(ref)
+	if n, err := strconv.Atoi(ref); err == nil {
+		entries, err := List()
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 || n > len(entries) {
+			return nil, appErrors.NewUserError("no library entry %d - run 'economist library list'", n)
+		}
+		return &entries[n-1], nil
+	}
+
+	entry, ok, err := Load(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, appErrors.NewUserError("not in library: %s", ref)
+	}
+	return entry, nil
+}
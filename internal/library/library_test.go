@@ -0,0 +1,117 @@
+package library
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/cache"
+)
+
+func setTempHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+}
+
+func saveTestArticle(t *testing.T, url, title, body string) {
+	t.Helper()
+	art := &article.Article{URL: url, Title: title, Blocks: article.ParagraphBlocks(body)}
+	if err := Save(art); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+}
+
+func TestSaveListRemove(t *testing.T) {
+	setTempHome(t)
+	saveTestArticle(t, "https://www.economist.com/a", "First", "About bonds.")
+	time.Sleep(10 * time.Millisecond)
+	saveTestArticle(t, "https://www.economist.com/b", "Second", "About trade.")
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Article.Title != "Second" {
+		t.Fatalf("expected newest first, got %#v", entries)
+	}
+	if !Contains("https://www.economist.com/a") {
+		t.Fatalf("expected saved article to be contained")
+	}
+
+	if ok, err := Remove("https://www.economist.com/a"); err != nil || !ok {
+		t.Fatalf("remove: ok=%v err=%v", ok, err)
+	}
+	entries, _ = List()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry after remove, got %d", len(entries))
+	}
+}
+
+func TestSaveKeepsOriginalSaveTime(t *testing.T) {
+	setTempHome(t)
+	saveTestArticle(t, "https://www.economist.com/a", "First", "Body.")
+	first, _, _ := Load("https://www.economist.com/a")
+
+	time.Sleep(10 * time.Millisecond)
+	saveTestArticle(t, "https://www.economist.com/a", "First, updated", "Body.")
+	second, _, _ := Load("https://www.economist.com/a")
+
+	if !second.SavedAt.Equal(first.SavedAt) || second.Article.Title != "First, updated" {
+		t.Fatalf("expected updated article with original save time")
+	}
+}
+
+func TestSearchAndResolve(t *testing.T) {
+	setTempHome(t)
+	saveTestArticle(t, "https://www.economist.com/a", "Argentina", "The bond restructuring went well.")
+	time.Sleep(10 * time.Millisecond)
+	saveTestArticle(t, "https://www.economist.com/b", "Trade", "Tariffs rose.")
+
+	matches, err := Search("Bond argentina")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Article.URL != "https://www.economist.com/a" {
+		t.Fatalf("unexpected matches: %#v", matches)
+	}
+
+	entry, err := Resolve("1")
+	if err != nil || entry.Article.Title != "Trade" {
+		t.Fatalf("expected first listed entry, got %v %v", entry, err)
+	}
+	if _, err := Resolve("3"); err == nil {
+		t.Fatalf("expected error for out of range number")
+	}
+	if _, err := Resolve("https://www.economist.com/missing"); err == nil {
+		t.Fatalf("expected error for unknown url")
+	}
+}
+
+func TestSourceServesSavedArticles(t *testing.T) {
+	setTempHome(t)
+	saveTestArticle(t, "https://www.economist.com/a", "Saved", "Offline body.")
+
+	source := NewSource(false)
+	title, items, err := source.Section(SectionName)
+	if err != nil || title != sectionTitle || len(items) != 1 {
+		t.Fatalf("unexpected section: %q %v %v", title, items, err)
+	}
+	if items[0].FormattedDate() == "" {
+		t.Fatalf("expected a parseable date")
+	}
+
+	art, err := source.Article(items[0].Link)
+	if err != nil || art.BodyText() != "Offline body." {
+		t.Fatalf("unexpected article: %v %v", art, err)
+	}
+
+	// A link followed out of a saved article is read like any other.
+	linked := &article.Article{URL: "https://www.economist.com/b", Title: "Linked", Blocks: article.ParagraphBlocks("Linked body.")}
+	if err := cache.SaveArticle(linked); err != nil {
+		t.Fatalf("cache: %v", err)
+	}
+	art, err = source.Article(linked.URL)
+	if err != nil || art.BodyText() != "Linked body." {
+		t.Fatalf("expected an unsaved link to be fetched, got %v %v", art, err)
+	}
+}
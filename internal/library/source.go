@@ -0,0 +1,68 @@
+package library
+
+import (
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/fetch"
+	"github.com/tmustier/economist-tui/internal/rss"
+)
+
+// SectionName is the only section a library source offers.
+const SectionName = "library"
+
+const sectionTitle = "Library"
+
+// Source serves saved articles to the browse TUI without network access.
+// Links followed out of a saved article are fetched as usual.
+type Source struct {
+	debug bool
+}
+
+func NewSource(debug bool) Source {
+	return Source{debug: debug}
+}
+
+func (Source) Section(section string) (string, []rss.Item, error) {
+	entries, err := List()
+	if err != nil {
+		return "", nil, err
+	}
+	return sectionTitle, Items(entries), nil
+}
+
+func (Source) Sections() []rss.SectionInfo {
+	return []rss.SectionInfo{{Primary: SectionName, Path: SectionName, Aliases: []string{SectionName}}}
+}
+
+func (s Source) Article(url string) (*article.Article, error) {
+	entry, ok, err := Load(url)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return fetch.FetchArticle(url, fetch.Options{Debug: s.debug})
+	}
+	return &entry.Article, nil
+}
+
+// Items converts entries to feed items, dated by publication when known
+// and by save time otherwise.
+func Items(entries []Entry) []rss.Item {
+	items := make([]rss.Item, 0, len(entries))
+	for _, entry := range entries {
+		art := entry.Article
+		date := art.Published
+		if date.IsZero() {
+			date = entry.SavedAt
+		}
+		items = append(items, rss.Item{
+			Title:       art.Title,
+			Description: art.Subtitle,
+			Link:        art.URL,
+			GUID:        art.URL,
+			PubDate:     date.Format(time.RFC1123Z),
+		})
+	}
+	return items
+}
@@ -0,0 +1,175 @@
+package readstate
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const (
+	stateDirName  = "readstate"
+	stateFileName = "state.json"
+
+	// readRetention bounds how long an opened headline is remembered;
+	// feeds only carry the last few weeks of items.
+	readRetention = 90 * 24 * time.Hour
+)
+
+// State records which headlines have been opened, keyed by feed GUID or
+// link, and when each scope was last checked for new headlines. It is
+// safe for concurrent use; the zero of *State (nil) tracks nothing.
+type State struct {
+	mu      sync.Mutex
+	read    map[string]time.Time
+	lastRun map[string]time.Time
+	loadErr error
+}
+
+type stateFile struct {
+	Read    map[string]time.Time `json:"read"`
+	LastRun map[string]time.Time `json:"last_run,omitempty"`
+}
+
+func Path() string {
+	return filepath.Join(config.ConfigDir(), stateDirName, stateFileName)
+}
+
+// Load reads the saved state, or returns an empty one if none exists.
+// When the file cannot be read the empty state is returned with the
+// error, and it refuses to Save so the file is not overwritten.
+func Load() (*State, error) {
+	s := New()
+	file, err := readFile()
+	if err != nil {
+		s.loadErr = err
+		return s, err
+	}
+	s.merge(file)
+	return s, nil
+}
+
+func readFile() (stateFile, error) {
+	var file stateFile
+	_, err := store.ReadJSON(Path(), &file)
+	return file, err
+}
+
+// merge adds the file's read marks and runs, keeping the later time where
+// both have one. The caller holds s.mu or owns s.
+func (s *State) merge(file stateFile) {
+	for key, at := range file.Read {
+		if at.After(s.read[key]) {
+			s.read[key] = at
+		}
+	}
+	for scope, at := range file.LastRun {
+		if at.After(s.lastRun[scope]) {
+			s.lastRun[scope] = at
+		}
+	}
+}
+
+// New returns an empty state.
+func New() *State {
+	return &State{read: make(map[string]time.Time), lastRun: make(map[string]time.Time)}
+}
+
+// Save writes the state, forgetting headlines opened longer ago than the
+// retention period. Browse and the CLI may both hold a state, so what was
+// saved since this one was loaded is merged in first.
+func (s *State) Save() error {
+	if s == nil {
+		return nil
+	}
+	if s.loadErr != nil {
+		return fmt.Errorf("read state was not loaded, not saving: %w", s.loadErr)
+	}
+
+	unlock, err := store.Lock(Path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	saved, err := readFile()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.merge(saved)
+	cutoff := time.Now().Add(-readRetention)
+	file := stateFile{Read: make(map[string]time.Time, len(s.read)), LastRun: make(map[string]time.Time, len(s.lastRun))}
+	for key, at := range s.read {
+		if at.Before(cutoff) {
+			delete(s.read, key)
+			continue
+		}
+		file.Read[key] = at
+	}
+	for scope, at := range s.lastRun {
+		file.LastRun[scope] = at
+	}
+	s.mu.Unlock()
+	return store.WriteJSON(Path(), file)
+}
+
+// IsRead reports whether the headline has been opened.
+func (s *State) IsRead(key string) bool {
+	if s == nil || key == "" {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.read[key]
+	return ok
+}
+
+// MarkRead records the headline as opened now. It reports whether the
+// headline was unread before.
+func (s *State) MarkRead(key string) bool {
+	if s == nil || key == "" {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, seen := s.read[key]
+	s.read[key] = time.Now().UTC()
+	return !seen
+}
+
+// UnreadCount returns how many of the keys have not been opened.
+func (s *State) UnreadCount(keys []string) int {
+	count := 0
+	for _, key := range keys {
+		if !s.IsRead(key) {
+			count++
+		}
+	}
+	return count
+}
+
+// LastRun returns when the scope was last checked for new headlines, or
+// the zero time if it never was.
+func (s *State) LastRun(scope string) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastRun[scope]
+}
+
+// SetLastRun records when the scope was checked for new headlines.
+func (s *State) SetLastRun(scope string, at time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastRun[scope] = at.UTC()
+}
@@ -0,0 +1,122 @@
+package readstate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMarkReadRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if s.IsRead("guid-1") {
+		t.Fatalf("expected empty state")
+	}
+	if !s.MarkRead("guid-1") {
+		t.Fatalf("expected first mark to report a change")
+	}
+	if s.MarkRead("guid-1") {
+		t.Fatalf("expected second mark to report no change")
+	}
+	since := time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	s.SetLastRun("headlines:leaders", since)
+	if err := s.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !loaded.IsRead("guid-1") || loaded.IsRead("guid-2") {
+		t.Fatalf("expected read state to survive a reload")
+	}
+	if got := loaded.LastRun("headlines:leaders"); !got.Equal(since) {
+		t.Fatalf("expected last run %v, got %v", since, got)
+	}
+	if got := loaded.UnreadCount([]string{"guid-1", "guid-2", "guid-3"}); got != 2 {
+		t.Fatalf("expected 2 unread, got %d", got)
+	}
+}
+
+func TestSaveForgetsOldReads(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s := New()
+	s.read["old"] = time.Now().Add(-readRetention - time.Hour)
+	s.MarkRead("new")
+	if err := s.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, _ := Load()
+	if loaded.IsRead("old") || !loaded.IsRead("new") {
+		t.Fatalf("expected only recent reads to be kept")
+	}
+}
+
+func TestSaveMergesWithSavedState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	browse, _ := Load()
+	cli, _ := Load()
+	since := time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	cli.MarkRead("from-cli")
+	cli.SetLastRun("headlines:leaders", since)
+	if err := cli.Save(); err != nil {
+		t.Fatalf("save cli: %v", err)
+	}
+	browse.MarkRead("from-browse")
+	if err := browse.Save(); err != nil {
+		t.Fatalf("save browse: %v", err)
+	}
+
+	loaded, _ := Load()
+	if !loaded.IsRead("from-cli") || !loaded.IsRead("from-browse") {
+		t.Fatalf("expected reads from both states to be kept")
+	}
+	if got := loaded.LastRun("headlines:leaders"); !got.Equal(since) {
+		t.Fatalf("expected the other state's last run kept, got %v", got)
+	}
+}
+
+func TestSaveRefusesAfterFailedLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := os.MkdirAll(filepath.Dir(Path()), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(Path(), []byte("{not json"), 0600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	s, err := Load()
+	if err == nil {
+		t.Fatalf("expected a corrupt file to fail to load")
+	}
+	s.MarkRead("guid")
+	if err := s.Save(); err == nil {
+		t.Fatalf("expected save to refuse after a failed load")
+	}
+	data, _ := os.ReadFile(Path())
+	if string(data) != "{not json" {
+		t.Fatalf("expected the file left alone, got %q", data)
+	}
+}
+
+func TestNilStateTracksNothing(t *testing.T) {
+	var s *State
+	if s.MarkRead("guid") || s.IsRead("guid") {
+		t.Fatalf("expected nil state to ignore reads")
+	}
+	if got := s.UnreadCount([]string{"a", "b"}); got != 2 {
+		t.Fatalf("expected everything unread, got %d", got)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+}
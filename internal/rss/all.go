@@ -0,0 +1,105 @@
+package rss
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tmustier/economist-tui/internal/search"
+)
+
+// SearchAll searches every section's feed, reading the RSS cache where it
+// is fresh. Items listed in several feeds are returned once.
+func SearchAll(query string) ([]Item, error) {
+	q, err := search.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := FetchAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Item
+	for _, item := range items {
+		if q.Matches(item.SearchFields(item.Sections...)) {
+			results = append(results, item)
+		}
+	}
+	return results, nil
+}
+
+// FetchAll merges the items of every section in SectionList.
+func FetchAll() ([]Item, error) {
+	return MergeSections(SectionList(), func(section string) ([]Item, error) {
+		feed, err := FetchSection(section)
+		if err != nil {
+			return nil, err
+		}
+		return feed.Channel.Items, nil
+	})
+}
+
+// MergeSections loads the sections in parallel and merges their items,
+// newest first. Each item is labelled with every section listing it, the
+// first in list order as its Section. Sections that fail to load are
+// skipped unless all of them fail.
+func MergeSections(sections []SectionInfo, load func(section string) ([]Item, error)) ([]Item, error) {
+	feeds := make([][]Item, len(sections))
+	errs := make([]error, len(sections))
+
+	var wg sync.WaitGroup
+	for i, info := range sections {
+		wg.Add(1)
+		go func(i int, section string) {
+			defer wg.Done()
+			feeds[i], errs[i] = load(section)
+		}(i, info.Primary)
+	}
+	wg.Wait()
+
+	var merged []Item
+	var firstErr error
+	loaded := 0
+	seen := make(map[string]int)
+	for i, info := range sections {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		loaded++
+		for _, item := range feeds[i] {
+			key := item.Key()
+			if at, ok := seen[key]; ok {
+				merged[at].Sections = append(merged[at].Sections, info.Primary)
+				continue
+			}
+			seen[key] = len(merged)
+			item.Section = info.Primary
+			item.Sections = []string{info.Primary}
+			merged = append(merged, item)
+		}
+	}
+	if loaded == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		ti, _ := parsePubDate(merged[i].PubDate)
+		tj, _ := parsePubDate(merged[j].PubDate)
+		return ti.After(tj)
+	})
+	return merged, nil
+}
+
+// Key identifies an item across feeds: its GUID, or its link when the
+// feed gives none.
+func (i Item) Key() string {
+	if guid := strings.TrimSpace(i.GUID); guid != "" {
+		return guid
+	}
+	return strings.TrimSpace(i.Link)
+}
@@ -0,0 +1,77 @@
+package rss
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/search"
+)
+
+func TestMergeSectionsDedupesByGUID(t *testing.T) {
+	sections := []SectionInfo{{Primary: "china"}, {Primary: "finance"}, {Primary: "leaders"}}
+	feeds := map[string][]Item{
+		"china": {
+			{Title: "Trade piece", GUID: "g1", PubDate: "Mon, 14 Sep 2026 10:00:00 +0000"},
+		},
+		"finance": {
+			{Title: "Trade piece", GUID: "g1", PubDate: "Mon, 14 Sep 2026 10:00:00 +0000"},
+			{Title: "Bond piece", Link: "https://www.economist.com/finance/bonds", PubDate: "Tue, 15 Sep 2026 10:00:00 +0000"},
+		},
+	}
+	items, err := MergeSections(sections, func(section string) ([]Item, error) {
+		if section == "leaders" {
+			return nil, errors.New("offline")
+		}
+		return feeds[section], nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 merged items, got %d", len(items))
+	}
+	if items[0].Title != "Bond piece" || items[0].Section != "finance" {
+		t.Fatalf("expected newest item first with its section, got %#v", items[0])
+	}
+	if items[1].Section != "china" {
+		t.Fatalf("expected duplicate to keep the first section, got %q", items[1].Section)
+	}
+	if len(items[1].Sections) != 2 || items[1].Sections[1] != "finance" {
+		t.Fatalf("expected duplicate to list every section, got %v", items[1].Sections)
+	}
+}
+
+func TestSearchFieldsMatchAnySection(t *testing.T) {
+	item := Item{Title: "Trade piece", Sections: []string{"china", "finance"}}
+	for _, query := range []string{"section:china", "section:finance-and-economics"} {
+		q, err := search.ParseQuery(query)
+		if err != nil {
+			t.Fatalf("parse %q: %v", query, err)
+		}
+		if !q.Matches(item.SearchFields(item.Sections...)) {
+			t.Fatalf("expected %q to match an item listed in both sections", query)
+		}
+	}
+}
+
+func TestMergeSectionsAllFailing(t *testing.T) {
+	_, err := MergeSections([]SectionInfo{{Primary: "leaders"}}, func(string) ([]Item, error) {
+		return nil, errors.New("offline")
+	})
+	if err == nil {
+		t.Fatalf("expected error when every section fails")
+	}
+}
+
+func TestSectionNames(t *testing.T) {
+	names := SectionNames("finance")
+	want := map[string]bool{"finance": true, "finance-and-economics": true}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for _, name := range names {
+		if !want[name] {
+			t.Fatalf("unexpected section name %q", name)
+		}
+	}
+}
@@ -38,6 +38,20 @@ func loadCachedSection(sectionPath string) ([]byte, time.Time, bool, error) {
 	return entry.Body, entry.CachedAt, true, nil
 }
 
+// CachedSection returns the section's feed as last fetched and when it was
+// fetched, without going to the network however old it is.
+func CachedSection(section string) (*RSS, time.Time, bool) {
+	body, cachedAt, ok, err := loadCachedSection(SectionPath(section))
+	if err != nil || !ok {
+		return nil, time.Time{}, false
+	}
+	feed, err := parseRSS(body)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	return feed, cachedAt, true
+}
+
 func saveCachedSection(sectionPath string, body []byte) error {
 	if err := os.MkdirAll(rssCacheDir(), 0755); err != nil {
 		return err
@@ -47,12 +47,17 @@ type Channel struct {
 	Items   []Item `xml:"item"`
 }
 
+// Item is a feed entry. Section and Sections are set only when items from
+// several feeds are merged: Sections names every feed listing the item, in
+// list order, and Section the first of them.
 type Item struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	Link        string `xml:"link"`
-	GUID        string `xml:"guid"`
-	PubDate     string `xml:"pubDate"`
+	Title       string   `xml:"title"`
+	Description string   `xml:"description"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Section     string   `xml:"-"`
+	Sections    []string `xml:"-"`
 }
 
 func (i Item) CleanTitle() string {
@@ -77,6 +82,11 @@ func (i Item) CompactDate() string {
 	return strings.TrimSpace(i.PubDate)
 }
 
+// Published returns the item's publication time, if the feed gave one.
+func (i Item) Published() (time.Time, bool) {
+	return parsePubDate(i.PubDate)
+}
+
 func parsePubDate(pubDate string) (time.Time, bool) {
 	formats := []string{
 		time.RFC1123Z,
@@ -113,7 +123,7 @@ func ordinalSuffix(day int) string {
 }
 
 func FetchSection(section string) (*RSS, error) {
-	sectionPath := resolveSection(section)
+	sectionPath := SectionPath(section)
 	url := fmt.Sprintf("https://www.economist.com/%s/rss.xml", sectionPath)
 
 	cachedBody, cachedAt, cachedOK, _ := loadCachedSection(sectionPath)
@@ -170,7 +180,14 @@ func parseRSS(body []byte) (*RSS, error) {
 	return &rss, nil
 }
 
+// Search returns the section's items matching the query; see
+// search.ParseQuery for the syntax.
 func Search(section, query string) ([]Item, error) {
+	q, err := search.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
 	rss, err := FetchSection(section)
 	if err != nil {
 		return nil, err
@@ -179,7 +196,7 @@ func Search(section, query string) ([]Item, error) {
 	var results []Item
 
 	for _, item := range rss.Channel.Items {
-		if matchesQuery(item, query) {
+		if q.Matches(item.SearchFields(section)) {
 			results = append(results, item)
 		}
 	}
@@ -187,14 +204,26 @@ func Search(section, query string) ([]Item, error) {
 	return results, nil
 }
 
-func resolveSection(section string) string {
+// SectionPath returns the feed path for a section name or alias.
+func SectionPath(section string) string {
 	if path, ok := Sections[strings.ToLower(section)]; ok {
 		return path
 	}
 	return section
 }
 
-func matchesQuery(item Item, query string) bool {
-	text := item.CleanTitle() + " " + item.CleanDescription()
-	return search.Match(text, query)
+// SearchFields returns what a search query is matched against for an item
+// listed in the given sections.
+func (i Item) SearchFields(sections ...string) search.Fields {
+	published, _ := parsePubDate(i.PubDate)
+	var names []string
+	for _, section := range sections {
+		names = append(names, SectionNames(section)...)
+	}
+	return search.Fields{
+		Title:       i.CleanTitle(),
+		Description: i.CleanDescription(),
+		Sections:    names,
+		Published:   published,
+	}
 }
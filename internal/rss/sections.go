@@ -33,6 +33,25 @@ func SectionList() []SectionInfo {
 	return sections
 }
 
+// SectionNames returns every name a section goes by: the name given, its
+// feed path and the aliases of that path.
+func SectionNames(section string) []string {
+	if section == "" {
+		return nil
+	}
+	path := SectionPath(section)
+	names := []string{section}
+	if path != section {
+		names = append(names, path)
+	}
+	for alias, aliasPath := range Sections {
+		if aliasPath == path && alias != section && alias != path {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
+
 func shortestString(strs []string) string {
 	if len(strs) == 0 {
 		return ""
@@ -0,0 +1,541 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+	"github.com/tmustier/economist-tui/internal/config"
+	"github.com/tmustier/economist-tui/internal/store"
+)
+
+const (
+	indexDirName  = "search"
+	indexFileName = "index.json"
+	docsDirName   = "docs"
+	queueDirName  = "pending"
+
+	// indexVersion is bumped whenever tokenizing or the file layout
+	// changes; an index written by another version is rebuilt from the
+	// stored document text.
+	indexVersion = 3
+
+	// BM25 parameters.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Document is an indexed article. Text is what was tokenized and what
+// snippets are cut from; it is kept in a file of its own, so documents
+// loaded with the index have no text until they are returned as hits.
+type Document struct {
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Subtitle  string    `json:"subtitle,omitempty"`
+	Section   string    `json:"section,omitempty"`
+	Published time.Time `json:"published,omitzero"`
+	Text      string    `json:"text,omitempty"`
+	Length    int       `json:"length"`
+}
+
+// Index is an inverted index over article text. The postings and document
+// metadata are stored as one compact JSON file, and each document's text
+// in a file of its own next to it.
+//
+// Reading an article only writes its document file and queues it; the
+// queue is worked into the postings in one go the next time the index is
+// opened for a search, so reads never rewrite the whole index.
+type Index struct {
+	Version     int                      `json:"version"`
+	NextID      int                      `json:"next_id"`
+	Docs        map[int]*Document        `json:"docs"`
+	IDs         map[string]int           `json:"ids"`
+	Postings    map[string]map[int][]int `json:"postings"`
+	Terms       map[int][]string         `json:"terms"` // each document's distinct terms, for Remove
+	TotalLength int                      `json:"total_length"`
+
+	path    string
+	added   map[string]bool
+	removed map[string]bool
+}
+
+var indexMu sync.Mutex
+
+func IndexPath() string {
+	return filepath.Join(config.ConfigDir(), indexDirName, indexFileName)
+}
+
+func docsDir(path string) string {
+	return filepath.Join(filepath.Dir(path), docsDirName)
+}
+
+func docPath(path, url string) string {
+	return filepath.Join(docsDir(path), store.KeyName(url))
+}
+
+func queueDir(path string) string {
+	return filepath.Join(filepath.Dir(path), queueDirName)
+}
+
+func newIndex(path string) *Index {
+	return &Index{
+		Version:  indexVersion,
+		Docs:     make(map[int]*Document),
+		IDs:      make(map[string]int),
+		Postings: make(map[string]map[int][]int),
+		Terms:    make(map[int][]string),
+		path:     path,
+		added:    make(map[string]bool),
+		removed:  make(map[string]bool),
+	}
+}
+
+// OpenIndex loads the index from disk, or returns an empty one, with the
+// articles queued since it was last saved added. An index that fails to
+// decode or was written by another version is rebuilt from the stored
+// document text.
+func OpenIndex() (*Index, error) {
+	path := IndexPath()
+	ix, rebuilt, err := loadIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	queued, err := queuedKeys(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(queued) == 0 && !rebuilt {
+		return ix, nil
+	}
+	return flushQueue(path)
+}
+
+// flushQueue adds the queued documents to the index and saves it. It runs
+// under a lock shared between processes, and reloads the index under it
+// in case another process flushed first.
+func flushQueue(path string) (*Index, error) {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	unlock, err := store.Lock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ix, _, err := loadIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	queued, err := queuedKeys(path)
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[string]bool)
+	for _, q := range queued {
+		if !done[q.key] {
+			done[q.key] = true
+			var doc Document
+			if ok, err := store.ReadJSON(filepath.Join(docsDir(path), q.key+".json"), &doc); err == nil && ok && doc.URL != "" {
+				ix.Add(doc)
+				delete(ix.added, doc.URL)
+			}
+		}
+	}
+	if err := ix.Save(); err != nil {
+		return nil, err
+	}
+	for _, q := range queued {
+		store.Remove(filepath.Join(queueDir(path), q.name))
+	}
+	return ix, nil
+}
+
+type queuedDoc struct {
+	key  string // the document file's name without extension
+	name string // the queue entry's file name
+}
+
+// queuedKeys lists the queue entries, oldest first. Each read adds an
+// entry of its own, so one written during a flush is left for the next.
+func queuedKeys(path string) ([]queuedDoc, error) {
+	files, err := os.ReadDir(queueDir(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	queued := make([]queuedDoc, 0, len(files))
+	for _, file := range files {
+		key, _, ok := strings.Cut(file.Name(), ".")
+		if file.IsDir() || !ok || key == "" {
+			continue
+		}
+		queued = append(queued, queuedDoc{key: key, name: file.Name()})
+	}
+	return queued, nil
+}
+
+// loadIndex reads the index file, rebuilding it when it is unreadable or
+// out of date, and reports whether it did so.
+func loadIndex(path string) (*Index, bool, error) {
+	ix := newIndex(path)
+	ok, err := store.ReadJSON(path, ix)
+	if err != nil && !isCorrupt(err) {
+		return nil, false, err
+	}
+	if err != nil || (ok && ix.Version != indexVersion) {
+		rebuilt, err := rebuildIndex(path, ix.Docs)
+		return rebuilt, true, err
+	}
+	if !ok {
+		return newIndex(path), false, nil
+	}
+	if ix.Docs == nil {
+		ix.Docs = make(map[int]*Document)
+	}
+	if ix.IDs == nil {
+		ix.IDs = make(map[string]int)
+	}
+	if ix.Postings == nil {
+		ix.Postings = make(map[string]map[int][]int)
+	}
+	if ix.Terms == nil {
+		ix.Terms = make(map[int][]string)
+	}
+	return ix, false, nil
+}
+
+func isCorrupt(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// rebuildIndex re-tokenizes every stored document. Documents decoded from
+// an older index that still carry their text are kept as well.
+func rebuildIndex(path string, old map[int]*Document) (*Index, error) {
+	ix := newIndex(path)
+	for _, doc := range old {
+		if doc != nil && doc.URL != "" && doc.Text != "" {
+			ix.Add(*doc)
+		}
+	}
+
+	files, err := os.ReadDir(docsDir(path))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		var doc Document
+		if ok, err := store.ReadJSON(filepath.Join(docsDir(path), file.Name()), &doc); err != nil || !ok || doc.URL == "" {
+			continue
+		}
+		if _, ok := ix.IDs[doc.URL]; !ok {
+			ix.Add(doc)
+			delete(ix.added, doc.URL)
+		}
+	}
+	return ix, nil
+}
+
+// Save writes the text of documents added since the index was opened,
+// then the index itself, and finally drops the text of removed documents.
+func (ix *Index) Save() error {
+	for url := range ix.added {
+		id, ok := ix.IDs[url]
+		if !ok {
+			continue
+		}
+		if err := store.WriteCompactJSON(docPath(ix.path, url), ix.Docs[id]); err != nil {
+			return err
+		}
+	}
+
+	saved := *ix
+	saved.Docs = make(map[int]*Document, len(ix.Docs))
+	for id, doc := range ix.Docs {
+		meta := *doc
+		meta.Text = ""
+		saved.Docs[id] = &meta
+	}
+	if err := store.WriteCompactJSON(ix.path, &saved); err != nil {
+		return err
+	}
+
+	for url := range ix.removed {
+		if _, ok := ix.IDs[url]; !ok {
+			store.Remove(docPath(ix.path, url))
+		}
+	}
+	ix.added = make(map[string]bool)
+	ix.removed = make(map[string]bool)
+	return nil
+}
+
+// Len returns the number of indexed documents.
+func (ix *Index) Len() int {
+	return len(ix.Docs)
+}
+
+// IndexArticle queues the article for the on-disk index, replacing any
+// earlier version of it. Only the article's own document file is written;
+// OpenIndex adds it to the postings.
+func IndexArticle(art *article.Article) error {
+	path := IndexPath()
+	doc := DocumentFromArticle(art)
+	if err := store.WriteCompactJSON(docPath(path, doc.URL), &doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(queueDir(path), 0755); err != nil {
+		return err
+	}
+	key := strings.TrimSuffix(store.KeyName(doc.URL), ".json")
+	entry, err := os.CreateTemp(queueDir(path), key+".*")
+	if err != nil {
+		return err
+	}
+	return entry.Close()
+}
+
+// DocumentFromArticle builds the document indexed for an article.
+func DocumentFromArticle(art *article.Article) Document {
+	parts := []string{art.Title}
+	if art.Subtitle != "" {
+		parts = append(parts, art.Subtitle)
+	}
+	parts = append(parts, art.BodyText())
+	return Document{
+		URL:       art.URL,
+		Title:     art.Title,
+		Subtitle:  art.Subtitle,
+		Section:   art.Section,
+		Published: art.Published,
+		Text:      strings.Join(parts, "\n\n"),
+	}
+}
+
+// Add indexes the document, replacing any document with the same URL.
+func (ix *Index) Add(doc Document) {
+	ix.Remove(doc.URL)
+
+	tokens := Tokenize(doc.Text)
+	id := ix.NextID
+	ix.NextID++
+
+	doc.Length = len(tokens)
+	ix.Docs[id] = &doc
+	ix.IDs[doc.URL] = id
+	ix.TotalLength += doc.Length
+	ix.added[doc.URL] = true
+
+	var terms []string
+	for _, token := range tokens {
+		postings := ix.Postings[token.Term]
+		if postings == nil {
+			postings = make(map[int][]int)
+			ix.Postings[token.Term] = postings
+		}
+		if _, seen := postings[id]; !seen {
+			terms = append(terms, token.Term)
+		}
+		postings[id] = append(postings[id], token.Pos)
+	}
+	ix.Terms[id] = terms
+}
+
+// Remove drops the document with the URL. It reports whether one existed.
+func (ix *Index) Remove(url string) bool {
+	id, ok := ix.IDs[url]
+	if !ok {
+		return false
+	}
+	for _, term := range ix.Terms[id] {
+		postings := ix.Postings[term]
+		delete(postings, id)
+		if len(postings) == 0 {
+			delete(ix.Postings, term)
+		}
+	}
+	ix.TotalLength -= ix.Docs[id].Length
+	delete(ix.Terms, id)
+	delete(ix.Docs, id)
+	delete(ix.IDs, url)
+	delete(ix.added, url)
+	ix.removed[url] = true
+	return true
+}
+
+// text returns the document's text, reading it from disk when the
+// document was loaded with the index.
+func (ix *Index) text(doc *Document) string {
+	if doc.Text != "" || ix.path == "" {
+		return doc.Text
+	}
+	var stored Document
+	if ok, err := store.ReadJSON(docPath(ix.path, doc.URL), &stored); err != nil || !ok {
+		return ""
+	}
+	return stored.Text
+}
+
+// Hit is a ranked search result.
+type Hit struct {
+	Document
+	Score   float64
+	Snippet Snippet
+}
+
+// Search ranks documents against the query with BM25. Bare words are
+// optional and add to the score; every quoted phrase must match.
+func (ix *Index) Search(query string, limit int) []Hit {
+	q := parseTextQuery(query)
+	if len(q.terms) == 0 && len(q.phrases) == 0 {
+		return nil
+	}
+
+	scores := ix.score(q.allTerms())
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		if !ix.matchesPhrases(id, q.phrases) {
+			continue
+		}
+		doc := ix.Docs[id]
+		hits = append(hits, Hit{Document: *doc, Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].Published.Equal(hits[j].Published) {
+			return hits[i].Published.After(hits[j].Published)
+		}
+		return hits[i].URL < hits[j].URL
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	matchTerms := make(map[string]bool)
+	for _, term := range q.allTerms() {
+		matchTerms[term] = true
+	}
+	for i := range hits {
+		hits[i].Text = ix.text(ix.Docs[ix.IDs[hits[i].URL]])
+		hits[i].Snippet = MakeSnippet(hits[i].Text, matchTerms, snippetWidth)
+	}
+	return hits
+}
+
+func (ix *Index) score(terms []string) map[int]float64 {
+	scores := make(map[int]float64)
+	n := float64(len(ix.Docs))
+	if n == 0 {
+		return scores
+	}
+	avgLen := float64(ix.TotalLength) / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := ix.Postings[term]
+		df := float64(len(postings))
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, positions := range postings {
+			tf := float64(len(positions))
+			docLen := float64(ix.Docs[id].Length)
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLen/avgLen))
+		}
+	}
+	return scores
+}
+
+func (ix *Index) matchesPhrases(id int, phrases [][]Token) bool {
+	for _, phrase := range phrases {
+		if !ix.matchesPhrase(id, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesPhrase checks that the phrase's terms occur in the document at
+// the same relative positions as in the query.
+func (ix *Index) matchesPhrase(id int, phrase []Token) bool {
+	if len(phrase) == 0 {
+		return true
+	}
+	first := ix.Postings[phrase[0].Term][id]
+	for _, start := range first {
+		matched := true
+		for _, token := range phrase[1:] {
+			want := start + token.Pos - phrase[0].Pos
+			if !containsInt(ix.Postings[token.Term][id], want) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(sorted []int, want int) bool {
+	i := sort.SearchInts(sorted, want)
+	return i < len(sorted) && sorted[i] == want
+}
+
+type textQuery struct {
+	terms   []string
+	phrases [][]Token
+}
+
+// parseTextQuery splits a query into bare words and "quoted phrases".
+func parseTextQuery(query string) textQuery {
+	var q textQuery
+	parts := strings.Split(query, "\"")
+	for i, part := range parts {
+		if i%2 == 1 {
+			if tokens := Tokenize(part); len(tokens) > 0 {
+				q.phrases = append(q.phrases, tokens)
+			}
+			continue
+		}
+		q.terms = append(q.terms, Terms(part)...)
+	}
+	return q
+}
+
+func (q textQuery) allTerms() []string {
+	terms := append([]string(nil), q.terms...)
+	for _, phrase := range q.phrases {
+		for _, token := range phrase {
+			terms = append(terms, token.Term)
+		}
+	}
+	return terms
+}
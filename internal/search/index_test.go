@@ -0,0 +1,190 @@
+package search
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func testIndex() *Index {
+	ix := newIndex("")
+	ix.Add(Document{
+		URL:   "https://www.economist.com/americas/argentina",
+		Title: "Argentina's creditors brace for another haircut",
+		Text: "Argentina's creditors brace for another haircut. The government wants to restructure " +
+			"its bonds for the tenth time. Investors who bought the bonds in 2020 are unimpressed.",
+	})
+	ix.Add(Document{
+		URL:   "https://www.economist.com/finance/banks",
+		Title: "Central banks and their discontents",
+		Text:  "Central banks face questions about independence. A bond sell-off rattled markets.",
+	})
+	ix.Add(Document{
+		URL:   "https://www.economist.com/science/whales",
+		Title: "Why whales sing",
+		Text:  "Humpback whales sing long songs. Nobody is quite sure why.",
+	})
+	return ix
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("The bank's bonds, restructured.")
+	want := []Token{
+		{Term: "bank", Pos: 1, Start: 4, End: 10},
+		{Term: "bond", Pos: 2, Start: 11, End: 16},
+		{Term: "restructur", Pos: 3, Start: 18, End: 30},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("expected %d tokens, got %#v", len(want), tokens)
+	}
+	for i := range want {
+		if tokens[i] != want[i] {
+			t.Fatalf("token %d: expected %#v, got %#v", i, want[i], tokens[i])
+		}
+	}
+}
+
+func TestSearchRanksByRelevance(t *testing.T) {
+	hits := testIndex().Search("argentine bond restructuring", 10)
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got %d", len(hits))
+	}
+	if !strings.HasSuffix(hits[0].URL, "/argentina") {
+		t.Fatalf("expected argentina article first, got %s", hits[0].URL)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Fatalf("expected descending scores, got %f then %f", hits[0].Score, hits[1].Score)
+	}
+}
+
+func TestSearchPhrase(t *testing.T) {
+	ix := testIndex()
+	if hits := ix.Search(`"central banks"`, 10); len(hits) != 1 || !strings.HasSuffix(hits[0].URL, "/banks") {
+		t.Fatalf("expected phrase to match the banks article, got %#v", hits)
+	}
+	if hits := ix.Search(`"banks central"`, 10); len(hits) != 0 {
+		t.Fatalf("expected reversed phrase not to match, got %d hits", len(hits))
+	}
+}
+
+func TestIndexReplaceAndRemove(t *testing.T) {
+	ix := testIndex()
+	ix.Add(Document{URL: "https://www.economist.com/science/whales", Title: "Whales", Text: "Whales hum."})
+	if ix.Len() != 3 {
+		t.Fatalf("expected replacement to keep 3 documents, got %d", ix.Len())
+	}
+	if hits := ix.Search("sing", 10); len(hits) != 0 {
+		t.Fatalf("expected old text to be gone, got %d hits", len(hits))
+	}
+
+	if !ix.Remove("https://www.economist.com/science/whales") {
+		t.Fatalf("expected remove to report an existing document")
+	}
+	if hits := ix.Search("whales", 10); len(hits) != 0 {
+		t.Fatalf("expected no hits after remove, got %d", len(hits))
+	}
+	if _, ok := ix.Postings["hum"]; ok {
+		t.Fatalf("expected postings of removed document to be dropped")
+	}
+}
+
+func TestIndexArticleRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	art := &article.Article{
+		URL:    "https://www.economist.com/finance/bonds",
+		Title:  "Bond vigilantes return",
+		Blocks: article.ParagraphBlocks("Yields jumped as investors sold government debt."),
+	}
+	if err := IndexArticle(art); err != nil {
+		t.Fatalf("index: %v", err)
+	}
+
+	ix, err := OpenIndex()
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	hits := ix.Search("yield", 10)
+	if len(hits) != 1 || hits[0].Title != art.Title {
+		t.Fatalf("expected saved index to find the article, got %#v", hits)
+	}
+}
+
+func TestIndexKeepsTextOutOfIndexFileAndRebuilds(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	art := &article.Article{
+		URL:    "https://www.economist.com/finance/bonds",
+		Title:  "Bond vigilantes return",
+		Blocks: article.ParagraphBlocks("Yields jumped as investors sold government debt."),
+	}
+	if err := IndexArticle(art); err != nil {
+		t.Fatalf("index: %v", err)
+	}
+	if _, err := os.Stat(IndexPath()); !os.IsNotExist(err) {
+		t.Fatalf("expected a read to queue the article without writing the index, got %v", err)
+	}
+	if _, err := OpenIndex(); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if queued, err := queuedKeys(IndexPath()); err != nil || len(queued) != 0 {
+		t.Fatalf("expected opening the index to empty the queue, got %v, %v", queued, err)
+	}
+
+	data, err := os.ReadFile(IndexPath())
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if strings.Contains(string(data), "investors sold") || strings.Contains(string(data), "\n ") {
+		t.Fatalf("expected a compact index without article text, got %s", data)
+	}
+
+	if err := os.WriteFile(IndexPath(), data[:len(data)/2], 0600); err != nil {
+		t.Fatalf("truncate index: %v", err)
+	}
+	ix, err := OpenIndex()
+	if err != nil {
+		t.Fatalf("expected a corrupt index to be rebuilt, got %v", err)
+	}
+	hits := ix.Search("yield", 10)
+	if len(hits) != 1 || !strings.Contains(hits[0].Snippet.Text, "Yields jumped") {
+		t.Fatalf("expected the rebuilt index to find the article, got %#v", hits)
+	}
+
+	other := &article.Article{
+		URL:    "https://www.economist.com/science/whales",
+		Title:  "Why whales sing",
+		Blocks: article.ParagraphBlocks("Humpback whales sing long songs."),
+	}
+	if err := IndexArticle(other); err != nil {
+		t.Fatalf("index after corruption: %v", err)
+	}
+	ix, err = OpenIndex()
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if ix.Len() != 2 {
+		t.Fatalf("expected both articles indexed, got %d", ix.Len())
+	}
+}
+
+func TestMakeSnippetHighlights(t *testing.T) {
+	text := strings.Repeat("Filler words before the match. ", 20) +
+		"The bonds were restructured in 2020. " + strings.Repeat("More filler afterwards. ", 20)
+	snippet := MakeSnippet(text, map[string]bool{"bond": true, "restructur": true}, 120)
+
+	if !strings.HasPrefix(snippet.Text, snippetEllipsis) || !strings.HasSuffix(snippet.Text, snippetEllipsis) {
+		t.Fatalf("expected snippet cut on both sides, got %q", snippet.Text)
+	}
+	words := snippet.Words()
+	if len(words) != 2 || words[0] != "bonds" || words[1] != "restructured" {
+		t.Fatalf("expected highlighted words, got %#v", words)
+	}
+	for _, r := range snippet.Highlights {
+		if strings.ContainsAny(snippet.Text[r.Start:r.End], " .") {
+			t.Fatalf("expected highlight on whole words, got %q", snippet.Text[r.Start:r.End])
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package search
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	appErrors "github.com/tmustier/economist-tui/internal/errors"
+)
+
+// Fields are the parts of a headline a query is matched against. Sections
+// lists every name the item's section goes by, so "section:finance" and
+// "section:finance-and-economics" both match. A zero Published never
+// matches a date filter.
+type Fields struct {
+	Title       string
+	Description string
+	Sections    []string
+	Published   time.Time
+}
+
+type clauseKind int
+
+const (
+	clauseText clauseKind = iota
+	clauseTitle
+	clauseDesc
+	clauseSection
+	clauseBefore
+	clauseAfter
+)
+
+var clauseFields = map[string]clauseKind{
+	"title":       clauseTitle,
+	"desc":        clauseDesc,
+	"description": clauseDesc,
+	"section":     clauseSection,
+	"before":      clauseBefore,
+	"after":       clauseAfter,
+}
+
+var dateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+type clause struct {
+	kind   clauseKind
+	value  string
+	phrase bool
+	negate bool
+	date   time.Time
+}
+
+// Query is a parsed headline filter. Clauses are ANDed together, except
+// that clauses joined by OR form a group of which one must match.
+type Query struct {
+	groups [][]clause
+}
+
+// ParseQuery parses a headline filter:
+//
+//	china tariffs        both words, fuzzily, in the title or description
+//	"trade war"          the exact phrase
+//	title:china          only in the title (also desc:, section:)
+//	after:2026-09-01     published on or after the date (also before:)
+//	-tariffs             exclude matches
+//	china OR india       either word
+//
+// Fuzzy matching is only used for bare words being searched for. Excluded
+// words and words in a field match the start of a word, so -war drops
+// "trade war" and "warships" but not "Washington".
+//
+// Dates may be given as 2026-09-01, 2026-09 or 2026.
+func ParseQuery(input string) (Query, error) {
+	var q Query
+	joinNext := false
+	for _, word := range splitQuery(input) {
+		if word == "OR" {
+			joinNext = len(q.groups) > 0
+			continue
+		}
+		c, ok, err := parseClause(word)
+		if err != nil {
+			return Query{}, err
+		}
+		if !ok {
+			continue
+		}
+		if joinNext {
+			last := len(q.groups) - 1
+			q.groups[last] = append(q.groups[last], c)
+		} else {
+			q.groups = append(q.groups, []clause{c})
+		}
+		joinNext = false
+	}
+	return q, nil
+}
+
+// Empty reports whether the query has no clauses and so matches anything.
+func (q Query) Empty() bool {
+	return len(q.groups) == 0
+}
+
+// Matches reports whether the fields satisfy the query.
+func (q Query) Matches(f Fields) bool {
+	for _, group := range q.groups {
+		matched := false
+		for _, c := range group {
+			if c.matches(f) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// splitQuery splits on whitespace outside double quotes, keeping the
+// quotes so phrases can be told apart from bare words.
+func splitQuery(input string) []string {
+	var words []string
+	var sb strings.Builder
+	inQuote := false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			sb.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if sb.Len() > 0 {
+				words = append(words, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() > 0 {
+		words = append(words, sb.String())
+	}
+	return words
+}
+
+func parseClause(word string) (clause, bool, error) {
+	var c clause
+	if len(word) > 1 && word[0] == '-' {
+		c.negate = true
+		word = word[1:]
+	}
+
+	if name, value, ok := strings.Cut(word, ":"); ok {
+		if kind, known := clauseFields[strings.ToLower(name)]; known {
+			c.kind = kind
+			word = value
+		}
+	}
+
+	if strings.HasPrefix(word, "\"") {
+		c.phrase = true
+		word = strings.Trim(word, "\"")
+	}
+	c.value = strings.ToLower(strings.TrimSpace(word))
+	if c.value == "" {
+		return clause{}, false, nil
+	}
+
+	if c.kind == clauseBefore || c.kind == clauseAfter {
+		date, err := parseQueryDate(c.value)
+		if err != nil {
+			return clause{}, false, err
+		}
+		c.date = date
+	}
+	return c, true, nil
+}
+
+func parseQueryDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, appErrors.NewUserError("invalid date %q in search - use YYYY-MM-DD", value)
+}
+
+func (c clause) matches(f Fields) bool {
+	return c.test(f) != c.negate
+}
+
+func (c clause) test(f Fields) bool {
+	switch c.kind {
+	case clauseTitle:
+		return c.matchText(f.Title)
+	case clauseDesc:
+		return c.matchText(f.Description)
+	case clauseSection:
+		for _, name := range f.Sections {
+			if strings.EqualFold(name, c.value) {
+				return true
+			}
+		}
+		return false
+	case clauseBefore:
+		return !f.Published.IsZero() && f.Published.Before(c.date)
+	case clauseAfter:
+		return !f.Published.IsZero() && !f.Published.Before(c.date)
+	}
+	return c.matchText(f.Title + " " + f.Description)
+}
+
+func (c clause) matchText(text string) bool {
+	text = strings.ToLower(text)
+	switch {
+	case c.phrase:
+		return strings.Contains(text, c.value)
+	case c.negate || c.kind != clauseText:
+		return containsWordPrefix(text, c.value)
+	}
+	return fuzzyContains(text, c.value)
+}
+
+// containsWordPrefix reports whether value occurs in text starting at the
+// beginning of a word.
+func containsWordPrefix(text, value string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], value)
+		if i < 0 {
+			return false
+		}
+		i += offset
+		if i == 0 {
+			return true
+		}
+		prev, _ := utf8.DecodeLastRuneInString(text[:i])
+		if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+			return true
+		}
+		offset = i + 1
+	}
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQueryMatches(t *testing.T) {
+	item := Fields{
+		Title:       "China's exporters shrug off tariffs",
+		Description: "A trade war that never quite arrived",
+		Sections:    []string{"finance", "finance-and-economics"},
+		Published:   time.Date(2026, 9, 14, 10, 0, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"china tariffs", true},
+		{"chna", true},
+		{"china japan", false},
+		{`"trade war"`, true},
+		{`"war trade"`, false},
+		{"title:china", true},
+		{"desc:china", false},
+		{`desc:"never quite"`, true},
+		{"section:finance-and-economics", true},
+		{"section:FINANCE", true},
+		{"section:fin", false},
+		{"after:2026-09-01", true},
+		{"after:2026-09-15", false},
+		{"before:2026-10", true},
+		{"before:2026-09-14", false},
+		{"after:2026-09-01 china -tariffs", false},
+		{"-japan", true},
+		{`-"trade war"`, false},
+		{"japan OR china", true},
+		{"japan OR kenya", false},
+		{"tariffs japan OR kenya", false},
+		{"tariffs japan OR china", true},
+	}
+	for _, tc := range cases {
+		q, err := ParseQuery(tc.query)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", tc.query, err)
+		}
+		if got := q.Matches(item); got != tc.want {
+			t.Errorf("%q: expected %v, got %v", tc.query, tc.want, got)
+		}
+	}
+}
+
+func TestExclusionsMatchWholeWords(t *testing.T) {
+	unrelated := []Fields{
+		{Title: "China's property crisis deepens", Description: "Beijing unveils a housing rescue plan for firms"},
+		{Title: "How Washington learned to love markets"},
+	}
+	for _, query := range []string{"-tariffs", "-war"} {
+		q, err := ParseQuery(query)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", query, err)
+		}
+		for _, item := range unrelated {
+			if !q.Matches(item) {
+				t.Errorf("%q: expected %q to survive", query, item.Title)
+			}
+		}
+	}
+
+	q, _ := ParseQuery("-war")
+	if q.Matches(Fields{Title: "Warships gather"}) || q.Matches(Fields{Title: "The trade war"}) {
+		t.Fatalf("expected -war to exclude words starting with war")
+	}
+	q, _ = ParseQuery("title:war")
+	if q.Matches(Fields{Title: "How Washington learned to love markets"}) {
+		t.Fatalf("expected a field clause not to match fuzzily")
+	}
+}
+
+func TestParseQueryDateFilterSkipsUndated(t *testing.T) {
+	q, err := ParseQuery("after:2026")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if q.Matches(Fields{Title: "Undated"}) {
+		t.Fatalf("expected undated item not to match a date filter")
+	}
+}
+
+func TestParseQueryInvalidDate(t *testing.T) {
+	if _, err := ParseQuery("after:last-week"); err == nil {
+		t.Fatalf("expected error for invalid date")
+	}
+}
+
+func TestParseQueryUnknownFieldIsText(t *testing.T) {
+	q, err := ParseQuery("covid:19")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !q.Matches(Fields{Title: "covid:19 cases"}) || q.Matches(Fields{Title: "covid cases"}) {
+		t.Fatalf("expected unknown field to be matched as plain text")
+	}
+}
@@ -1,24 +1,5 @@
 package search
 
-import "strings"
-
-func Match(text, query string) bool {
-	query = strings.TrimSpace(query)
-	if query == "" {
-		return true
-	}
-
-	text = strings.ToLower(text)
-	tokens := strings.Fields(strings.ToLower(query))
-
-	for _, token := range tokens {
-		if !fuzzyContains(text, token) {
-			return false
-		}
-	}
-	return true
-}
-
 // fuzzyContains checks if all characters in query appear in text in order.
 func fuzzyContains(text, query string) bool {
 	if query == "" {
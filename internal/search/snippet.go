@@ -0,0 +1,126 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetWidth    = 200
+	snippetLead     = 60
+	snippetEllipsis = "…"
+)
+
+// Range is a byte range within a snippet.
+type Range struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Snippet is an excerpt around the best match, with the matched words
+// marked by Highlights.
+type Snippet struct {
+	Text       string  `json:"text"`
+	Highlights []Range `json:"highlights,omitempty"`
+}
+
+// Words returns the highlighted words, lower-cased.
+func (s Snippet) Words() []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, r := range s.Highlights {
+		word := strings.ToLower(s.Text[r.Start:r.End])
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// MakeSnippet cuts about width bytes of text around the window with the
+// most matching terms.
+func MakeSnippet(text string, terms map[string]bool, width int) Snippet {
+	tokens := Tokenize(text)
+	var matches []Token
+	for _, token := range tokens {
+		if terms[token.Term] {
+			matches = append(matches, token)
+		}
+	}
+
+	start := 0
+	if len(matches) > 0 {
+		best, bestCount := 0, 0
+		for i, m := range matches {
+			count := 0
+			for _, other := range matches[i:] {
+				if other.End-m.Start > width-snippetLead {
+					break
+				}
+				count++
+			}
+			if count > bestCount {
+				best, bestCount = i, count
+			}
+		}
+		start = matches[best].Start - snippetLead
+	}
+	start = wordStart(text, start)
+	end := wordEnd(text, start+width)
+
+	var sb strings.Builder
+	offset := 0
+	if start > 0 {
+		sb.WriteString(snippetEllipsis)
+		offset = len(snippetEllipsis)
+	}
+	sb.WriteString(strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, text[start:end]))
+	if end < len(text) {
+		sb.WriteString(snippetEllipsis)
+	}
+
+	var highlights []Range
+	for _, m := range matches {
+		if m.Start >= start && m.End <= end {
+			highlights = append(highlights, Range{Start: m.Start - start + offset, End: m.End - start + offset})
+		}
+	}
+	return Snippet{Text: sb.String(), Highlights: highlights}
+}
+
+// wordStart moves i back to the start of the word it falls in.
+func wordStart(text string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	if i >= len(text) {
+		return len(text)
+	}
+	for i > 0 && !isSpaceByte(text[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd moves i back to the end of the last whole word before it.
+func wordEnd(text string, i int) int {
+	if i >= len(text) {
+		return len(text)
+	}
+	for j := i; j > 0; j-- {
+		if isSpaceByte(text[j]) {
+			return len(strings.TrimRightFunc(text[:j], unicode.IsSpace))
+		}
+	}
+	return i
+}
+
+func isSpaceByte(b byte) bool {
+	return b == ' ' || b == '\n' || b == '\t'
+}
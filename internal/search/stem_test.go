@@ -0,0 +1,40 @@
+package search
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	cases := map[string]string{
+		"caresses":        "caress",
+		"ponies":          "poni",
+		"cats":            "cat",
+		"feed":            "feed",
+		"agreed":          "agre",
+		"plastered":       "plaster",
+		"motoring":        "motor",
+		"sing":            "sing",
+		"conflated":       "conflat",
+		"troubled":        "troubl",
+		"sized":           "size",
+		"hopping":         "hop",
+		"falling":         "fall",
+		"filing":          "file",
+		"happy":           "happi",
+		"relational":      "relat",
+		"conditional":     "condit",
+		"generalizations": "gener",
+		"oscillators":     "oscil",
+		"hopeful":         "hope",
+		"electricity":     "electr",
+		"adjustable":      "adjust",
+		"controlling":     "control",
+		"restructuring":   "restructur",
+		"restructured":    "restructur",
+		"bonds":           "bond",
+		"is":              "is",
+	}
+	for word, expected := range cases {
+		if got := Stem(word); got != expected {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, expected)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is one indexed word: its stemmed term, its position among the
+// words of the text, and its byte range in the text.
+type Token struct {
+	Term  string
+	Pos   int
+	Start int
+	End   int
+}
+
+// stopWords are skipped when indexing but still take up a position, so
+// phrase queries that span them still line up.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "for": true, "from": true, "has": true,
+	"have": true, "in": true, "is": true, "it": true, "its": true, "of": true,
+	"on": true, "or": true, "that": true, "the": true, "this": true, "to": true,
+	"was": true, "were": true, "which": true, "with": true,
+}
+
+// Tokenize splits text into lower-cased, stemmed words. Apostrophes inside
+// words are dropped ("Argentina's" becomes "argentinas").
+func Tokenize(text string) []Token {
+	var tokens []Token
+	pos := 0
+	start := -1
+	var word strings.Builder
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		raw := word.String()
+		if !stopWords[raw] {
+			tokens = append(tokens, Token{Term: Stem(raw), Pos: pos, Start: start, End: end})
+		}
+		pos++
+		start = -1
+		word.Reset()
+	}
+
+	for i, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if start < 0 {
+				start = i
+			}
+			word.WriteRune(unicode.ToLower(r))
+		case (r == '\'' || r == '’') && start >= 0 && nextIsLetter(text, i+utf8.RuneLen(r)):
+			// Keep the word going across an inner apostrophe.
+		default:
+			flush(i)
+		}
+	}
+	flush(len(text))
+	return tokens
+}
+
+func nextIsLetter(text string, i int) bool {
+	if i >= len(text) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return unicode.IsLetter(r)
+}
+
+// Terms returns the stemmed terms of text, in order.
+func Terms(text string) []string {
+	tokens := Tokenize(text)
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = token.Term
+	}
+	return terms
+}
@@ -0,0 +1,94 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteReadJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "data.json")
+	in := map[string]int{"a": 1}
+
+	if err := WriteJSON(path, in); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var out map[string]int
+	ok, err := ReadJSON(path, &out)
+	if err != nil || !ok {
+		t.Fatalf("read: ok=%v err=%v", ok, err)
+	}
+	if out["a"] != 1 {
+		t.Fatalf("unexpected data: %v", out)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected 0600, got %v", info.Mode().Perm())
+	}
+
+	files, _ := os.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Fatalf("expected no temp files left behind, got %d files", len(files))
+	}
+}
+
+func TestReadJSONMissing(t *testing.T) {
+	var out map[string]int
+	ok, err := ReadJSON(filepath.Join(t.TempDir(), "missing.json"), &out)
+	if err != nil || ok {
+		t.Fatalf("expected miss without error, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := WriteJSON(path, 1); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if ok, err := Remove(path); err != nil || !ok {
+		t.Fatalf("expected removal, got ok=%v err=%v", ok, err)
+	}
+	if ok, err := Remove(path); err != nil || ok {
+		t.Fatalf("expected no-op on second removal, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestLockExcludesOtherHolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "data.json")
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		second, err := Lock(path)
+		if err != nil {
+			t.Errorf("second lock: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- second
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatalf("expected the second lock to wait for the first")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case second := <-acquired:
+		if second != nil {
+			second()
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the second lock once the first was released")
+	}
+}
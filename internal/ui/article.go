@@ -3,7 +3,11 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/lipgloss"
+	cansi "github.com/charmbracelet/x/ansi"
 	"github.com/tmustier/economist-tui/internal/article"
 )
 
@@ -36,14 +40,274 @@ func RenderArticleHeaderWithLayout(art *article.Article, styles ArticleStyles, l
 	return sb.String()
 }
 
+// ArticleBodyMarkdown returns the body as markdown for glamour. Glamour
+// prints markdown link targets inline after the text, and would mangle
+// escape sequences placed in its input, so link text is marked with
+// private-use runes instead; RenderArticleBodyBase turns the marks into
+// OSC 8 hyperlinks once glamour is done.
+//
+// The text of paragraphs with links is protected the same way, since
+// glamour prints backslash escapes as written.
 func ArticleBodyMarkdown(art *article.Article) string {
+	parts := make([]string, 0, len(art.Blocks))
+	for _, block := range art.Blocks {
+		md := block.Markdown()
+		if block.Kind == article.BlockParagraph && len(block.Spans) > 0 {
+			md = markSpans(block.Spans)
+		}
+		if md != "" {
+			parts = append(parts, md)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ArticleBodySource returns the body text fed to RenderArticleBodyBase:
+// markdown when glamour renders the body, styled plain text otherwise.
+func ArticleBodySource(art *article.Article, opts ArticleRenderOptions) string {
+	if opts.NoColor || opts.PlainBody {
+		return articleBodyText(art, NewArticleStyles(opts.NoColor), opts.NoColor, opts.Marks)
+	}
+	return ArticleBodyMarkdown(art)
+}
+
+// ArticleBodyText renders the body blocks as plain text, one block per
+// paragraph. Headings, captions and links are styled word by word so the
+// style survives reflow; links become OSC 8 terminal hyperlinks.
+func ArticleBodyText(art *article.Article, styles ArticleStyles, noColor bool) string {
+	return articleBodyText(art, styles, noColor, nil)
+}
+
+func articleBodyText(art *article.Article, styles ArticleStyles, noColor bool, marks map[int]ParagraphMark) string {
+	parts := make([]string, 0, len(art.Blocks))
+	for i, block := range art.Blocks {
+		text := block.PlainText()
+		if text == "" {
+			continue
+		}
+		switch block.Kind {
+		case article.BlockParagraph:
+			mark := marks[i]
+			if !noColor && mark.Highlight {
+				text = styleWords(text, styles.Highlight)
+			} else if !noColor && len(block.Spans) > 0 {
+				text = renderSpans(block.Spans, styles.Link)
+			}
+			text = mark.prefix() + text
+		case article.BlockHeading:
+			if !noColor {
+				text = styleWords(text, styles.Title)
+			}
+		case article.BlockFigure:
+			if !noColor {
+				text = styleWords(text, styles.Date)
+			}
+		case article.BlockQuote:
+			lines := strings.Split(text, "\n")
+			for i, line := range lines {
+				lines[i] = quotePrefix + line
+			}
+			text = strings.Join(lines, "\n")
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func renderSpans(spans []article.Span, linkStyle lipgloss.Style) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		if span.URL == "" {
+			sb.WriteString(span.Text)
+			continue
+		}
+		sb.WriteString(hyperlinkWords(span.Text, span.URL, linkStyle))
+	}
+	return sb.String()
+}
+
+// hyperlinkWords wraps each word in its own hyperlink so a link broken
+// across lines does not swallow the indentation between them.
+func hyperlinkWords(text, url string, style lipgloss.Style) string {
+	var sb strings.Builder
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		sb.WriteString(cansi.SetHyperlink(url))
+		sb.WriteString(style.Render(text[start:end]))
+		sb.WriteString(cansi.ResetHyperlink())
+		start = -1
+	}
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			flush(i)
+			sb.WriteRune(r)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(text))
+	return sb.String()
+}
+
+// Link marks: linkMarkStart, the URL with each byte shifted into a
+// private-use plane, linkMarkText, the link text, then linkMarkEnd.
+// Characters markdown would read as formatting are shifted by
+// literalBase, so glamour passes them through untouched.
+const (
+	linkMarkStart = '\uE000'
+	linkMarkText  = '\uE001'
+	linkMarkEnd   = '\uE002'
+	linkURLBase   = 0xF0000
+	literalBase   = 0xF0100
+)
+
+// markSpans writes the spans as protected markdown with link marks around
+// the linked text.
+func markSpans(spans []article.Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		if span.URL == "" {
+			sb.WriteString(protectMarkdown(span.Text))
+			continue
+		}
+		sb.WriteRune(linkMarkStart)
+		for i := 0; i < len(span.URL); i++ {
+			sb.WriteRune(rune(linkURLBase + int(span.URL[i])))
+		}
+		sb.WriteRune(linkMarkText)
+		sb.WriteString(protectMarkdown(span.Text))
+		sb.WriteRune(linkMarkEnd)
+	}
+	return sb.String()
+}
+
+const markdownSpecials = "\\`*_{}[]<>()#+-!|~&"
+
+// protectMarkdown shifts the characters markdown would read as formatting
+// out of its way; applyLinkMarks shifts them back.
+func protectMarkdown(text string) string {
+	if !strings.ContainsAny(text, markdownSpecials) {
+		return text
+	}
 	var sb strings.Builder
-	if art.Content != "" {
-		sb.WriteString(art.Content)
+	for _, r := range text {
+		if r < utf8.RuneSelf && strings.ContainsRune(markdownSpecials, r) {
+			r += literalBase
+		}
+		sb.WriteRune(r)
 	}
 	return sb.String()
 }
 
+// applyLinkMarks replaces the link marks in rendered output with OSC 8
+// hyperlinks, one per word as in hyperlinkWords, and restores protected
+// characters. Escape sequences that glamour put between words are left
+// outside the hyperlinks.
+func applyLinkMarks(rendered string) string {
+	if !strings.ContainsFunc(rendered, isMarkRune) {
+		return rendered
+	}
+	var sb strings.Builder
+	var url strings.Builder
+	inURL, inText, inWord := false, false, false
+	endWord := func() {
+		if inWord {
+			sb.WriteString(cansi.ResetHyperlink())
+			inWord = false
+		}
+	}
+	for i := 0; i < len(rendered); {
+		if rendered[i] == '\x1b' {
+			n := escapeLen(rendered[i:])
+			endWord()
+			if !inURL {
+				sb.WriteString(rendered[i : i+n])
+			}
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(rendered[i:])
+		i += size
+		switch {
+		case r == linkMarkStart:
+			endWord()
+			url.Reset()
+			inURL, inText = true, false
+		case r == linkMarkText:
+			inURL, inText = false, true
+		case r == linkMarkEnd:
+			endWord()
+			inText = false
+		case inURL:
+			if r >= linkURLBase && r < linkURLBase+0x100 {
+				url.WriteByte(byte(r - linkURLBase))
+			}
+		case inText && !unicode.IsSpace(r):
+			if r >= literalBase && r < literalBase+utf8.RuneSelf {
+				r -= literalBase
+			}
+			if !inWord {
+				sb.WriteString(cansi.SetHyperlink(url.String()))
+				inWord = true
+			}
+			sb.WriteRune(r)
+		default:
+			endWord()
+			if r >= literalBase && r < literalBase+utf8.RuneSelf {
+				r -= literalBase
+			}
+			sb.WriteRune(r)
+		}
+	}
+	endWord()
+	return sb.String()
+}
+
+func isMarkRune(r rune) bool {
+	return r == linkMarkStart || r >= literalBase && r < literalBase+utf8.RuneSelf
+}
+
+// escapeLen returns the length of the escape sequence at the start of s:
+// CSI sequences up to their final byte, OSC sequences up to BEL or ST.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(s)
+}
+
+func styleWords(text string, style lipgloss.Style) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		words[i] = style.Render(word)
+	}
+	return strings.Join(words, " ")
+}
+
 func ArticleFooter(art *article.Article, styles ArticleStyles, opts ArticleRenderOptions) string {
 	return ArticleFooterWithLayout(art, styles, ResolveArticleLayout(opts), opts)
 }
@@ -98,3 +362,40 @@ func renderOvertitle(text string, styles ArticleStyles) string {
 
 	return fmt.Sprintf("%s | %s", styles.Section.Render(section), styles.Overtitle.Render(rest))
 }
+
+const (
+	// ParagraphCursorMarker runs down the margin of the paragraph selected
+	// in the reader.
+	ParagraphCursorMarker = "▌ "
+	annotationMarker      = "┃ "
+	noteMarker            = "✎ "
+)
+
+// ParagraphMark decorates a body paragraph in the reader. Marks are drawn
+// as a hanging margin prefix, so they follow the paragraph through reflow
+// and column changes.
+type ParagraphMark struct {
+	Cursor    bool
+	Highlight bool
+	Note      bool
+}
+
+func (m ParagraphMark) prefix() string {
+	switch {
+	case m.Cursor:
+		return ParagraphCursorMarker
+	case m.Note:
+		return noteMarker
+	case m.Highlight:
+		return annotationMarker
+	}
+	return ""
+}
+
+func styleParagraphMarkers(text string, styles ArticleStyles) string {
+	for _, marker := range []string{ParagraphCursorMarker, annotationMarker, noteMarker} {
+		glyph := strings.TrimSpace(marker)
+		text = strings.ReplaceAll(text, marker, styles.Marker.Render(glyph)+" ")
+	}
+	return text
+}
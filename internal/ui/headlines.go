@@ -63,6 +63,37 @@ func ResolveDateLayout(contentWidth, prefixWidth int) DateLayout {
 	}
 }
 
+// sectionLabelOverrides are section names that are not simply capitalised.
+var sectionLabelOverrides = map[string]string{
+	"us": "US",
+}
+
+// SectionLabel labels a headline with the section it came from, e.g.
+// "middle-east" becomes "[Middle East]".
+func SectionLabel(section string) string {
+	section = strings.TrimSpace(section)
+	if section == "" {
+		return ""
+	}
+	if label, ok := sectionLabelOverrides[strings.ToLower(section)]; ok {
+		return "[" + label + "]"
+	}
+	words := strings.Fields(strings.ReplaceAll(section, "-", " "))
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return "[" + strings.Join(words, " ") + "]"
+}
+
+// BadgedTitle prefixes the title with the badge, if any, so both wrap
+// together.
+func BadgedTitle(badge, title string) string {
+	if badge == "" {
+		return title
+	}
+	return badge + " " + title
+}
+
 func (l HeadlineLayout) PadTitle(title string) string {
 	truncated := Truncate(title, l.TitleWidth)
 	return fmt.Sprintf("%-*s", l.TitleWidth, truncated)
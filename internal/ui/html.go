@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// ArticleHTML renders articles as one standalone HTML page with embedded
+// styles, for archiving outside the terminal. Several articles get a
+// table of contents linking to each.
+func ArticleHTML(title string, articles []*article.Article) string {
+	if title == "" && len(articles) > 0 {
+		title = articles[0].Title
+	}
+
+	var body strings.Builder
+	if len(articles) > 1 {
+		body.WriteString("<nav class=\"contents\">\n")
+		fmt.Fprintf(&body, "<h1>%s</h1>\n<ol>\n", html.EscapeString(title))
+		for i, art := range articles {
+			fmt.Fprintf(&body, "<li><a href=\"#article-%d\">%s</a></li>\n", i+1, html.EscapeString(art.Title))
+		}
+		body.WriteString("</ol>\n</nav>\n")
+	}
+	for i, art := range articles {
+		body.WriteString(ArticleHTMLSection(art, fmt.Sprintf("article-%d", i+1)))
+	}
+	return HTMLDocument(title, body.String())
+}
+
+// HTMLDocument wraps body markup in a standalone page carrying the reader
+// stylesheet.
+func HTMLDocument(title, body string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en-GB">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<style>
+%s</style>
+</head>
+<body>
+<main>
+%s</main>
+</body>
+</html>
+`, html.EscapeString(title), htmlStylesheet(), body)
+}
+
+// ArticleHTMLSection renders one article, headline block and body, as an
+// <article> element with the given id.
+func ArticleHTMLSection(art *article.Article, id string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<article id=\"%s\">\n<header>\n", html.EscapeString(id))
+	if art.Overtitle != "" {
+		fmt.Fprintf(&b, "<p class=\"overtitle\">%s</p>\n", htmlOvertitle(art.Overtitle))
+	}
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(art.Title))
+	if art.Subtitle != "" {
+		fmt.Fprintf(&b, "<p class=\"subtitle\">%s</p>\n", html.EscapeString(art.Subtitle))
+	}
+	if art.DateLine != "" {
+		fmt.Fprintf(&b, "<p class=\"dateline\">%s</p>\n", html.EscapeString(art.DateLine))
+	}
+	if byline := art.BylineLine(); byline != "" {
+		fmt.Fprintf(&b, "<p class=\"byline\">%s</p>\n", html.EscapeString(byline))
+	}
+	b.WriteString("</header>\n")
+	b.WriteString(art.BodyHTML())
+	source := html.EscapeString(art.SourceURL())
+	fmt.Fprintf(&b, "\n<footer><a href=\"%s\">%s</a></footer>\n</article>\n", source, source)
+	return b.String()
+}
+
+// htmlOvertitle puts the section of "Section | Rubric" in the brand
+// colour, as the terminal header does.
+func htmlOvertitle(text string) string {
+	section, rest, ok := strings.Cut(text, "|")
+	if !ok {
+		return html.EscapeString(text)
+	}
+	return fmt.Sprintf("<span class=\"section\">%s</span> | %s",
+		html.EscapeString(strings.TrimSpace(section)), html.EscapeString(strings.TrimSpace(rest)))
+}
+
+// htmlStylesheet maps the terminal themes onto CSS: the light theme by
+// default and the dark one when the browser prefers it. The text measure
+// follows the reader's widths.
+func htmlStylesheet() string {
+	return fmt.Sprintf(`:root {
+%s}
+@media (prefers-color-scheme: dark) {
+  :root {
+%s  }
+}
+body { background: var(--background); color: var(--text); font: 1.125rem/1.6 Georgia, "Times New Roman", serif; margin: 0; }
+main { box-sizing: content-box; max-width: %[3]dch; min-width: min(%[4]dch, 100%%); margin: 0 auto; padding: 2rem 1.25rem 4rem; }
+@media (min-width: 90ch) { main { max-width: %[5]dch; } }
+article + article { border-top: 4px solid var(--brand); margin-top: 3rem; padding-top: 1rem; }
+header { border-bottom: 1px solid var(--border); margin-bottom: 1.5rem; padding-bottom: 1rem; }
+h1 { font-size: 2rem; line-height: 1.15; margin: 0.25rem 0 0.5rem; }
+h2 { font-size: 1.25rem; margin: 2rem 0 0.5rem; }
+h3 { font-size: 1.1rem; line-height: 1.3; margin: 1.25rem 0 0.25rem; }
+h3 a { color: inherit; text-decoration: none; }
+.summary { color: var(--text-muted); margin: 0; }
+.section-title { border-top: 4px solid var(--brand); margin-top: 2.5rem; padding-top: 0.5rem; }
+a { color: var(--accent); }
+.overtitle { color: var(--text-muted); font: 600 0.85rem/1.4 system-ui, sans-serif; margin: 0; }
+.overtitle .section { color: var(--brand); }
+.subtitle { color: var(--text-muted); font-size: 1.25rem; font-style: italic; margin: 0 0 0.75rem; }
+.dateline, .byline { color: var(--text-faint); font: 0.85rem/1.4 system-ui, sans-serif; margin: 0; }
+blockquote { border-left: 3px solid var(--brand); margin: 1.5rem 0; padding-left: 1rem; }
+figure { margin: 1.5rem 0; }
+figcaption { color: var(--text-muted); font: 0.85rem/1.4 system-ui, sans-serif; }
+table { border-collapse: collapse; font: 0.9rem/1.4 system-ui, sans-serif; margin: 1.5rem 0; }
+th, td { border-bottom: 1px solid var(--border); padding: 0.3rem 0.6rem; text-align: left; }
+hr { border: 0; border-top: 1px solid var(--border); margin: 2rem 0; }
+footer { color: var(--text-faint); font: 0.8rem/1.4 system-ui, sans-serif; margin-top: 2rem; overflow-wrap: anywhere; }
+footer a { color: inherit; }
+.contents { border-bottom: 4px solid var(--brand); margin-bottom: 2rem; }
+`, themeCSS(DefaultTheme, Chicago45, "  "), themeCSS(DarkTheme, Chicago90, "    "),
+		IdealWidth, MinReadableWidth, MaxReadableWidth)
+}
+
+func themeCSS(theme Theme, accent lipgloss.Color, indent string) string {
+	vars := []struct {
+		name  string
+		value string
+	}{
+		{"brand", string(theme.Brand)},
+		{"text", string(theme.Text)},
+		{"text-muted", string(theme.TextMuted)},
+		{"text-faint", string(theme.TextFaint)},
+		{"border", string(theme.Border)},
+		{"background", string(theme.Background)},
+		{"accent", string(accent)},
+	}
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%s--%s: %s;\n", indent, v.name, v.value)
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func TestArticleHTMLStandalone(t *testing.T) {
+	art := &article.Article{
+		Overtitle: "Finance & economics | Bonds",
+		Title:     "Yields <rise>",
+		Subtitle:  "Investors are nervous",
+		DateLine:  "Oct 15th 2026",
+		URL:       "https://www.economist.com/finance/bonds",
+		Blocks:    article.ParagraphBlocks("Yields rose.\n\nInvestors fled."),
+	}
+
+	out := ArticleHTML("", []*article.Article{art})
+	for _, want := range []string{
+		"<title>Yields &lt;rise&gt;</title>",
+		`<span class="section">Finance &amp; economics</span> | Bonds`,
+		"<p>Investors fled.</p>",
+		"--brand: " + string(EconomistRed),
+		"prefers-color-scheme: dark",
+		fmt.Sprintf("max-width: %dch", IdealWidth),
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in page:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<nav") || strings.Contains(out, "<link") || strings.Contains(out, "<script") {
+		t.Fatalf("expected a single self-contained article page")
+	}
+
+	other := &article.Article{Title: "Second", URL: "https://www.economist.com/china/exports"}
+	digest := ArticleHTML("Weekly", []*article.Article{art, other})
+	if !strings.Contains(digest, `<a href="#article-2">Second</a>`) || !strings.Contains(digest, `<article id="article-2">`) {
+		t.Fatalf("expected a table of contents linking each article:\n%s", digest)
+	}
+}
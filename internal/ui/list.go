@@ -3,15 +3,21 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
 // ListItem represents a row with an optional right-aligned column.
+// Badge is drawn before the title and Highlights are lower-cased words to
+// emphasise in the subtitle. Read rows use the Read title style.
 type ListItem struct {
-	Title    string
-	Subtitle string
-	Right    string
+	Title      string
+	Subtitle   string
+	Right      string
+	Badge      string
+	Highlights []string
+	Read       bool
 }
 
 // ListStyles controls how list rows are styled.
@@ -21,6 +27,9 @@ type ListStyles struct {
 	Selected      lipgloss.Style
 	Right         lipgloss.Style
 	RightSelected lipgloss.Style
+	Highlight     lipgloss.Style
+	Badge         lipgloss.Style
+	Read          lipgloss.Style
 }
 
 // ListOptions configures list rendering.
@@ -105,6 +114,9 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 	for i := opts.Start; i < opts.End; i++ {
 		item := items[i]
 		lineStyle := styles.Title
+		if item.Read {
+			lineStyle = styles.Read
+		}
 		rightStyle := styles.Right
 		if i == opts.SelectedIndex {
 			lineStyle = styles.Selected
@@ -116,7 +128,7 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 			prefix = opts.Prefix(i)
 		}
 
-		titleLines := LimitLines(WrapLines(item.Title, layout.TitleWidth), opts.TitleLines, layout.TitleWidth)
+		titleLines := LimitLines(WrapLines(BadgedTitle(item.Badge, item.Title), layout.TitleWidth), opts.TitleLines, layout.TitleWidth)
 		if len(titleLines) == 0 {
 			titleLines = []string{""}
 		}
@@ -124,6 +136,10 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 			if lineIdx == 0 {
 				paddedTitle := fmt.Sprintf("%-*s", layout.TitleWidth, line)
 				b.WriteString(prefix)
+				if item.Badge != "" && strings.HasPrefix(paddedTitle, item.Badge) {
+					b.WriteString(styles.Badge.Render(item.Badge))
+					paddedTitle = paddedTitle[len(item.Badge):]
+				}
 				b.WriteString(lineStyle.Render(paddedTitle))
 				if layout.RightWidth > 0 {
 					rightColumn := fmt.Sprintf("%*s", layout.RightWidth, item.Right)
@@ -145,7 +161,12 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 				b.WriteString(prefixPad + "\n")
 				continue
 			}
-			b.WriteString(fmt.Sprintf("%s%s\n", prefixPad, styles.Subtitle.Render(line)))
+			if len(item.Highlights) > 0 {
+				line = HighlightWords(line, item.Highlights, styles.Subtitle, styles.Highlight)
+			} else {
+				line = styles.Subtitle.Render(line)
+			}
+			b.WriteString(fmt.Sprintf("%s%s\n", prefixPad, line))
 		}
 
 		if gapLines > 0 {
@@ -155,3 +176,39 @@ func RenderList(items []ListItem, opts ListOptions, styles ListStyles) string {
 
 	return b.String()
 }
+
+// HighlightWords renders text word by word, using highlight for words whose
+// letters match one of words (case-insensitively) and base for the rest.
+// Punctuation around a matched word keeps the base style.
+func HighlightWords(text string, words []string, base, highlight lipgloss.Style) string {
+	match := make(map[string]bool, len(words))
+	for _, word := range words {
+		match[strings.ToLower(word)] = true
+	}
+	notWordRune := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+
+	fields := strings.Split(text, " ")
+	for i, field := range fields {
+		if field == "" {
+			continue
+		}
+		core := strings.TrimFunc(field, notWordRune)
+		if core == "" || !match[strings.ToLower(core)] {
+			fields[i] = base.Render(field)
+			continue
+		}
+		start := strings.Index(field, core)
+		var sb strings.Builder
+		if start > 0 {
+			sb.WriteString(base.Render(field[:start]))
+		}
+		sb.WriteString(highlight.Render(core))
+		if rest := field[start+len(core):]; rest != "" {
+			sb.WriteString(base.Render(rest))
+		}
+		fields[i] = sb.String()
+	}
+	return strings.Join(fields, " ")
+}
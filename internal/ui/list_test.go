@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/muesli/reflow/ansi"
 )
 
@@ -43,3 +44,12 @@ func TestRenderListAlignsRightColumn(t *testing.T) {
 		t.Fatalf("expected line width 20, got %d", width)
 	}
 }
+
+func TestHighlightWords(t *testing.T) {
+	base := lipgloss.NewStyle()
+	highlight := lipgloss.NewStyle().Transform(strings.ToUpper)
+	out := HighlightWords("Bonds, again: bonds!", []string{"bonds"}, base, highlight)
+	if out != "BONDS, again: BONDS!" {
+		t.Fatalf("unexpected highlight output %q", out)
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/charmbracelet/glamour"
 	cansi "github.com/charmbracelet/x/ansi"
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 	"github.com/tmustier/economist-tui/internal/article"
 )
@@ -19,8 +18,23 @@ const (
 	minColumnLines = 24
 	baseWrapWidth  = 2000
 	bodyIndent     = 2
+	quotePrefix    = "│ "
+	minHangWidth   = 16
 )
 
+// hangingPrefixes are line prefixes kept as a hanging indent when a body
+// line wraps; the value is the prefix used for continuation lines.
+var hangingPrefixes = []struct {
+	first string
+	rest  string
+}{
+	{first: "• ", rest: "  "},
+	{first: quotePrefix, rest: quotePrefix},
+	{first: ParagraphCursorMarker, rest: ParagraphCursorMarker},
+	{first: annotationMarker, rest: annotationMarker},
+	{first: noteMarker, rest: annotationMarker},
+}
+
 type ArticleRenderOptions struct {
 	Raw       bool
 	NoColor   bool
@@ -30,6 +44,10 @@ type ArticleRenderOptions struct {
 	MaxWidth  int
 	Center    bool
 	TwoColumn bool
+
+	// Marks decorates body paragraphs, keyed by block index. Only the
+	// plain-text body draws them.
+	Marks map[int]ParagraphMark
 }
 
 type ArticleLayout struct {
@@ -50,9 +68,9 @@ func RenderArticle(art *article.Article, opts ArticleRenderOptions) (string, err
 	}
 
 	styles := NewArticleStyles(opts.NoColor)
-	markdown := ArticleBodyMarkdown(art)
+	source := ArticleBodySource(art, opts)
 
-	base, err := RenderArticleBodyBase(markdown, opts)
+	base, err := RenderArticleBodyBase(source, opts)
 	if err != nil {
 		return "", err
 	}
@@ -227,7 +245,44 @@ func wrapBody(text string, width int) string {
 	if width <= 0 {
 		return text
 	}
-	return cansi.Wrap(text, width, "")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		first, rest, ok := hangingIndent(line)
+		hangWidth := width - cansi.StringWidth(first)
+		if !ok || hangWidth < minHangWidth {
+			lines[i] = cansi.Wrap(line, width, "")
+			continue
+		}
+		wrapped := strings.Split(cansi.Wrap(line[len(first):], hangWidth, ""), "\n")
+		for j := range wrapped {
+			if j == 0 {
+				wrapped[j] = first + wrapped[j]
+			} else {
+				wrapped[j] = rest + wrapped[j]
+			}
+		}
+		lines[i] = strings.Join(wrapped, "\n")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hangingIndent reports the list or quote prefix a line starts with and the
+// indent to use for its continuation lines.
+func hangingIndent(line string) (string, string, bool) {
+	for _, prefix := range hangingPrefixes {
+		if strings.HasPrefix(line, prefix.first) {
+			return prefix.first, prefix.rest, true
+		}
+	}
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits <= 3 && strings.HasPrefix(line[digits:], ". ") {
+		first := line[:digits+2]
+		return first, strings.Repeat(" ", len(first)), true
+	}
+	return "", "", false
 }
 
 func countWrappedLines(text string, width int) int {
@@ -276,7 +331,7 @@ func RenderArticleBodyBase(markdown string, opts ArticleRenderOptions) (string,
 		return "", err
 	}
 
-	return out, nil
+	return applyLinkMarks(out), nil
 }
 
 func ReflowArticleBody(base string, styles ArticleStyles, opts ArticleRenderOptions) string {
@@ -304,6 +359,7 @@ func ReflowArticleBodyWithLayout(base string, styles ArticleStyles, opts Article
 
 	if !opts.NoColor {
 		body = HighlightTrailingMarker(body, styles)
+		body = styleParagraphMarkers(body, styles)
 	}
 
 	totalIndent := innerIndent + outerPadding
@@ -367,7 +423,7 @@ func padRightANSI(text string, width int) string {
 		return text
 	}
 	trimmed := cansi.Truncate(text, width, "")
-	pad := width - ansi.PrintableRuneWidth(trimmed)
+	pad := width - cansi.StringWidth(trimmed)
 	if pad <= 0 {
 		return trimmed
 	}
@@ -423,25 +479,10 @@ func IndentBlock(text string, indent int) string {
 	return strings.Join(lines, "\n")
 }
 
+// isLineBlank reports whether a line holds only whitespace once escape
+// sequences (colors, hyperlinks) are removed.
 func isLineBlank(line string) bool {
-	inANSI := false
-	for _, r := range line {
-		switch {
-		case inANSI:
-			if ansi.IsTerminator(r) {
-				inANSI = false
-			}
-			continue
-		case r == ansi.Marker:
-			inANSI = true
-			continue
-		case r == ' ' || r == '\t':
-			continue
-		default:
-			return false
-		}
-	}
-	return true
+	return strings.Trim(cansi.Strip(line), " \t") == ""
 }
 
 func uintPtr(v uint) *uint {
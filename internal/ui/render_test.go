@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	cansi "github.com/charmbracelet/x/ansi"
 	"github.com/tmustier/economist-tui/internal/article"
 )
 
@@ -13,7 +14,7 @@ func TestRenderArticleIndent(t *testing.T) {
 		Title:     "Headline",
 		Subtitle:  "Subhead",
 		DateLine:  "Jan 1st 2024",
-		Content:   "This is a paragraph that should wrap across multiple lines to verify indentation is consistent across wraps.",
+		Blocks:    article.ParagraphBlocks("This is a paragraph that should wrap across multiple lines to verify indentation is consistent across wraps."),
 		URL:       "https://example.com/test",
 	}
 
@@ -130,3 +131,122 @@ func TestHighlightTrailingMarker(t *testing.T) {
 		t.Fatalf("expected marker replacement, got %q", output)
 	}
 }
+
+func TestWrapBodyKeepsHangingIndent(t *testing.T) {
+	input := "• A bullet item long enough that it has to wrap onto a second line"
+	lines := strings.Split(wrapBody(input, 30), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("expected wrapped lines, got %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], "• ") {
+		t.Fatalf("expected bullet on first line, got %q", lines[0])
+	}
+	for _, line := range lines[1:] {
+		if !strings.HasPrefix(line, "  ") {
+			t.Fatalf("expected hanging indent, got %q", line)
+		}
+	}
+}
+
+func TestArticleBodyTextNoColor(t *testing.T) {
+	art := &article.Article{Blocks: []article.Block{
+		{Kind: article.BlockParagraph, Text: "Intro."},
+		{Kind: article.BlockHeading, Text: "Crosshead", Level: 2},
+		{Kind: article.BlockQuote, Text: "Quoted"},
+		{Kind: article.BlockList, Items: []string{"one", "two"}},
+	}}
+
+	out := ArticleBodyText(art, NewArticleStyles(true), true)
+	expected := "Intro.\n\nCrosshead\n\n" + quotePrefix + "Quoted\n\n• one\n• two"
+	if out != expected {
+		t.Fatalf("unexpected body text: %q", out)
+	}
+}
+
+func TestArticleBodyTextHyperlinks(t *testing.T) {
+	art := &article.Article{Blocks: []article.Block{
+		{Kind: article.BlockParagraph, Text: "See the rates story.", Spans: []article.Span{
+			{Text: "See the "},
+			{Text: "rates story", URL: "https://www.economist.com/rates"},
+			{Text: "."},
+		}},
+	}}
+
+	out := ArticleBodyText(art, NewArticleStyles(false), false)
+	if !strings.Contains(out, "\x1b]8;;https://www.economist.com/rates") {
+		t.Fatalf("expected OSC 8 hyperlink, got %q", out)
+	}
+	if StripANSI(out) != "See the rates story." {
+		t.Fatalf("unexpected visible text: %q", StripANSI(out))
+	}
+
+	plain := ArticleBodyText(art, NewArticleStyles(true), true)
+	if plain != "See the rates story." {
+		t.Fatalf("expected plain text without links, got %q", plain)
+	}
+}
+
+func TestArticleBodyMarkdownHyperlinksSurviveGlamour(t *testing.T) {
+	url := "https://www.economist.com/finance_and_economics/2026/10/15/rates_*up*"
+	art := &article.Article{Blocks: []article.Block{
+		{Kind: article.BlockParagraph, Text: "See the_rate [story] now.", Spans: []article.Span{
+			{Text: "See "},
+			{Text: "the_rate [story]", URL: url},
+			{Text: " now."},
+		}},
+	}}
+	opts := ArticleRenderOptions{WrapWidth: 80}
+
+	base, err := RenderArticleBodyBase(ArticleBodySource(art, opts), opts)
+	if err != nil {
+		t.Fatalf("render base: %v", err)
+	}
+	if got := strings.Count(base, cansi.SetHyperlink(url)); got != 2 {
+		t.Fatalf("expected an intact hyperlink on each linked word, got %d in %q", got, base)
+	}
+	if strings.ContainsAny(base, string([]rune{linkMarkStart, linkMarkText, linkMarkEnd})) {
+		t.Fatalf("expected link marks removed, got %q", base)
+	}
+	if visible := strings.TrimSpace(StripANSI(base)); visible != "See the_rate [story] now." {
+		t.Fatalf("expected the text as written, got %q", visible)
+	}
+}
+
+func TestParagraphMarksSurviveReflowAndColumns(t *testing.T) {
+	long := strings.Repeat("annotated words ", 40)
+	art := &article.Article{Blocks: article.ParagraphBlocks("Plain opening.\n\n" + long)}
+	for _, twoColumn := range []bool{false, true} {
+		opts := ArticleRenderOptions{
+			NoColor:   true,
+			PlainBody: true,
+			WrapWidth: 80,
+			TwoColumn: twoColumn,
+			Marks:     map[int]ParagraphMark{1: {Highlight: true, Note: true}},
+		}
+		base, err := RenderArticleBodyBase(ArticleBodySource(art, opts), opts)
+		if err != nil {
+			t.Fatalf("render base: %v", err)
+		}
+		layout := ResolveArticleLayoutWithContent(base, opts)
+		if layout.UseColumns != twoColumn {
+			t.Fatalf("columns=%v: unexpected layout %#v", twoColumn, layout)
+		}
+		body := ReflowArticleBodyWithLayout(base, NewArticleStyles(true), opts, layout)
+
+		marked := 0
+		for _, line := range strings.Split(body, "\n") {
+			if strings.Contains(line, "annotated") {
+				if !strings.Contains(line, noteMarker) && !strings.Contains(line, annotationMarker) {
+					t.Fatalf("columns=%v: expected margin marker on %q", twoColumn, line)
+				}
+				marked++
+			}
+			if strings.Contains(line, "Plain opening.") && strings.Contains(line, noteMarker) {
+				t.Fatalf("columns=%v: unmarked paragraph has a marker: %q", twoColumn, line)
+			}
+		}
+		if marked < 2 || strings.Count(body, noteMarker) != 1 {
+			t.Fatalf("columns=%v: expected one note marker over %d wrapped lines, got %q", twoColumn, marked, body)
+		}
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/muesli/reflow/ansi"
+	cansi "github.com/charmbracelet/x/ansi"
 )
 
 const (
@@ -50,20 +50,5 @@ func IsRuleLine(line string) bool {
 }
 
 func StripANSI(text string) string {
-	var b strings.Builder
-	inSeq := false
-	for _, r := range text {
-		if inSeq {
-			if ansi.IsTerminator(r) {
-				inSeq = false
-			}
-			continue
-		}
-		if r == ansi.Marker {
-			inSeq = true
-			continue
-		}
-		b.WriteRune(r)
-	}
-	return b.String()
+	return cansi.Strip(text)
 }
@@ -16,6 +16,9 @@ type BrowseStyles struct {
 	Dim      lipgloss.Style
 	Help     lipgloss.Style
 	Search   lipgloss.Style
+	Match    lipgloss.Style // Matched words in full-text search snippets
+	Badge    lipgloss.Style // Section label on cross-section results
+	Read     lipgloss.Style // Titles of headlines already opened
 
 	// Search bar states
 	SearchIdle    lipgloss.Style // Placeholder "/ type to filter..."
@@ -53,6 +56,9 @@ type ArticleStyles struct {
 	Date      lipgloss.Style
 	Rule      lipgloss.Style
 	Body      lipgloss.Style
+	Link      lipgloss.Style
+	Highlight lipgloss.Style // Highlighted paragraphs in the reader
+	Marker    lipgloss.Style // Paragraph margin markers
 }
 
 func NewStyles(theme Theme, noColor bool) Styles {
@@ -116,6 +122,9 @@ func NewBrowseStyles(noColor bool) BrowseStyles {
 	dim := lipgloss.NewStyle().Foreground(theme.TextFaint)
 	help := lipgloss.NewStyle().Foreground(theme.TextFaint)
 	search := lipgloss.NewStyle().Foreground(theme.TextFaint)
+	match := lipgloss.NewStyle().Bold(true).Foreground(theme.Text)
+	badge := lipgloss.NewStyle().Foreground(theme.Brand)
+	read := lipgloss.NewStyle().Foreground(theme.TextMuted)
 
 	// Search bar styles
 	searchIdle := lipgloss.NewStyle().Foreground(theme.TextFaint).Padding(0, 1)
@@ -133,6 +142,9 @@ func NewBrowseStyles(noColor bool) BrowseStyles {
 		dim = lipgloss.NewStyle()
 		help = lipgloss.NewStyle()
 		search = lipgloss.NewStyle()
+		match = lipgloss.NewStyle().Bold(true)
+		badge = lipgloss.NewStyle()
+		read = lipgloss.NewStyle()
 		searchIdle = lipgloss.NewStyle()
 		searchActive = lipgloss.NewStyle()
 		searchCount = lipgloss.NewStyle()
@@ -148,6 +160,9 @@ func NewBrowseStyles(noColor bool) BrowseStyles {
 		Dim:           dim,
 		Help:          help,
 		Search:        search,
+		Match:         match,
+		Badge:         badge,
+		Read:          read,
 		SearchIdle:    searchIdle,
 		SearchActive:  searchActive,
 		SearchCount:   searchCount,
@@ -164,6 +179,9 @@ func NewArticleStyles(noColor bool) ArticleStyles {
 	subtitle := lipgloss.NewStyle().Foreground(theme.TextMuted)
 	date := lipgloss.NewStyle().Foreground(theme.TextFaint).Faint(true)
 	rule := lipgloss.NewStyle().Foreground(theme.Border)
+	link := lipgloss.NewStyle().Underline(true)
+	highlight := body.Copy().Background(Chicago20)
+	marker := lipgloss.NewStyle().Foreground(theme.Brand)
 
 	if noColor {
 		overtitle = lipgloss.NewStyle()
@@ -173,6 +191,9 @@ func NewArticleStyles(noColor bool) ArticleStyles {
 		date = lipgloss.NewStyle()
 		rule = lipgloss.NewStyle()
 		body = lipgloss.NewStyle()
+		link = lipgloss.NewStyle()
+		highlight = lipgloss.NewStyle()
+		marker = lipgloss.NewStyle()
 	}
 
 	return ArticleStyles{
@@ -183,5 +204,8 @@ func NewArticleStyles(noColor bool) ArticleStyles {
 		Date:      date,
 		Rule:      rule,
 		Body:      body,
+		Link:      link,
+		Highlight: highlight,
+		Marker:    marker,
 	}
 }
@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+// Status reports what WriteArticle did with the file.
+type Status int
+
+const (
+	Created Status = iota
+	Updated
+	Unchanged
+)
+
+func (s Status) String() string {
+	switch s {
+	case Created:
+		return "created"
+	case Updated:
+		return "updated"
+	default:
+		return "unchanged"
+	}
+}
+
+// Document returns the article as Markdown with YAML front matter.
+func Document(art *article.Article, tags []string) string {
+	return art.FrontMatter(tags) + art.ToMarkdown()
+}
+
+// WriteArticle writes the article into dir as Markdown with front matter,
+// named after the slug at the end of its URL. Exporting the same article
+// again rewrites the same file, and leaves it untouched when nothing
+// changed; a different article with the same slug gets a suffixed name.
+func WriteArticle(dir string, art *article.Article, tags []string) (string, Status, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", Created, err
+	}
+	content := []byte(Document(art, tags))
+	url := art.SourceURL()
+
+	name := FileName(url)
+	target := filepath.Join(dir, name+".md")
+	existing, err := os.ReadFile(target)
+	if err == nil && frontMatterURL(existing) != url {
+		sum := sha1.Sum([]byte(url))
+		target = filepath.Join(dir, fmt.Sprintf("%s-%x.md", name, sum[:4]))
+		existing, err = os.ReadFile(target)
+	}
+
+	status := Created
+	if err == nil {
+		if bytes.Equal(existing, content) {
+			return target, Unchanged, nil
+		}
+		status = Updated
+	} else if !os.IsNotExist(err) {
+		return "", Created, err
+	}
+	if err := os.WriteFile(target, content, 0644); err != nil {
+		return "", Created, err
+	}
+	return target, status, nil
+}
+
+// FileName derives a file name from the article URL's last path segment,
+// e.g. ".../2026/03/26/some-article" -> "some-article".
+func FileName(articleURL string) string {
+	trimmed := strings.TrimRight(strings.SplitN(articleURL, "?", 2)[0], "/")
+	name := path.Base(trimmed)
+	if name == "" || name == "." || name == "/" {
+		name = "article"
+	}
+	return name
+}
+
+// frontMatterURL returns the url field of a file written by WriteArticle.
+func frontMatterURL(data []byte) string {
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || lines[0] != "---" {
+		return ""
+	}
+	for _, line := range lines[1:] {
+		if line == "---" {
+			break
+		}
+		if value, ok := strings.CutPrefix(line, "url: "); ok {
+			if url, err := strconv.Unquote(value); err == nil {
+				return url
+			}
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package vault
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tmustier/economist-tui/internal/article"
+)
+
+func testArticle(url string) *article.Article {
+	return &article.Article{
+		Overtitle: "Finance & economics | Bonds",
+		Title:     `Why "safe" assets wobble`,
+		Subtitle:  "Investors are nervous",
+		Section:   "Finance & economics",
+		Published: time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC),
+		FetchedAt: time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC),
+		URL:       url,
+		Blocks:    article.ParagraphBlocks("Yields rose."),
+	}
+}
+
+func TestDocumentFrontMatter(t *testing.T) {
+	doc := Document(testArticle("https://www.economist.com/finance/2026/10/15/bonds"), []string{"markets", "weekly"})
+	want := "---\n" +
+		`title: "Why \"safe\" assets wobble"` + "\n" +
+		`subtitle: "Investors are nervous"` + "\n" +
+		`overtitle: "Finance & economics | Bonds"` + "\n" +
+		`section: "Finance & economics"` + "\n" +
+		"published: 2026-10-15\n" +
+		`url: "https://www.economist.com/finance/2026/10/15/bonds"` + "\n" +
+		"fetched: 2026-10-16T08:30:00Z\n" +
+		"tags:\n  - \"markets\"\n  - \"weekly\"\n" +
+		"---\n\n"
+	if !strings.HasPrefix(doc, want) {
+		t.Fatalf("unexpected front matter:\n%s", doc)
+	}
+	if !strings.Contains(doc, "# Why \"safe\" assets wobble") {
+		t.Fatalf("expected the article body after the front matter:\n%s", doc)
+	}
+}
+
+func TestWriteArticleIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	art := testArticle("https://www.economist.com/finance/2026/10/15/bonds")
+
+	path, status, err := WriteArticle(dir, art, nil)
+	if err != nil || status != Created || filepath.Base(path) != "bonds.md" {
+		t.Fatalf("expected bonds.md created, got %s %s (err %v)", path, status, err)
+	}
+	if _, status, _ = WriteArticle(dir, art, nil); status != Unchanged {
+		t.Fatalf("expected re-export to leave the file alone, got %s", status)
+	}
+	if _, status, _ = WriteArticle(dir, art, []string{"weekly"}); status != Updated {
+		t.Fatalf("expected new tags to update the file, got %s", status)
+	}
+
+	other := testArticle("https://www.economist.com/united-states/2026/10/15/bonds")
+	otherPath, status, err := WriteArticle(dir, other, nil)
+	if err != nil || status != Created || otherPath == path || !strings.HasPrefix(filepath.Base(otherPath), "bonds-") {
+		t.Fatalf("expected a suffixed file for a different article, got %s %s (err %v)", otherPath, status, err)
+	}
+	if _, status, _ = WriteArticle(dir, other, nil); status != Unchanged {
+		t.Fatalf("expected the suffixed file to be found again, got %s", status)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+}